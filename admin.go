@@ -0,0 +1,601 @@
+package xk6_mongo
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/topology"
+)
+
+// CurrentOp returns the operations currently in progress on the server that
+// match filter, e.g. { secs_running: { $gte: 5 } }, using the $currentOp
+// aggregation stage on the admin database.
+func (c *Client) CurrentOp(filter any) ([]bson.M, error) {
+	ctx, cancel := c.operationContext()
+	defer cancel()
+
+	if filter == nil {
+		filter = bson.D{}
+	}
+	pipeline := bson.A{
+		bson.D{{Key: "$currentOp", Value: bson.D{}}},
+		bson.D{{Key: "$match", Value: filter}},
+	}
+	cur, err := c.client.Database("admin").Aggregate(ctx, pipeline, c.aggregateOptions())
+	if err != nil {
+		log.Printf("Error while reading current operations: %v", err)
+		return nil, err
+	}
+	var results []bson.M
+	if err = cur.All(ctx, &results); err != nil {
+		log.Printf(errDecodingDocuments, err)
+		return nil, err
+	}
+	return results, nil
+}
+
+// KillOp terminates the server operation with the given opid, as listed by
+// CurrentOp.
+func (c *Client) KillOp(opId int64) error {
+	if c.dryRun("killOp", "admin", "$cmd", "op", opId) {
+		return nil
+	}
+
+	ctx, cancel := c.operationContext()
+	defer cancel()
+
+	if err := c.killOp(ctx, opId); err != nil {
+		log.Printf("Error while killing operation %d: %v", opId, err)
+		return err
+	}
+	return nil
+}
+
+// KillLongRunning kills the active user operations, i.e. queries, getMores,
+// writes and commands outside of the admin, local and config databases, that
+// have been running for at least thresholdMs milliseconds, and returns the
+// opids of the killed operations.
+func (c *Client) KillLongRunning(thresholdMs int64) ([]any, error) {
+	if thresholdMs < 0 {
+		return nil, fmt.Errorf("threshold cannot be negative, got %d", thresholdMs)
+	}
+	ops, err := c.CurrentOp(bson.D{
+		{Key: "active", Value: true},
+		{Key: "microsecs_running", Value: bson.D{{Key: "$gte", Value: thresholdMs * 1000}}},
+		{Key: "op", Value: bson.D{{Key: "$in", Value: bson.A{"query", "getmore", "insert", "update", "remove", "command"}}}},
+		{Key: "ns", Value: bson.D{{Key: "$not", Value: primitive.Regex{Pattern: `^(admin|local|config)\.`}}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if c.dryRunEnabled {
+		opids := make([]any, 0, len(ops))
+		for _, op := range ops {
+			if opid, ok := op["opid"]; ok {
+				opids = append(opids, opid)
+			}
+		}
+		c.dryRun("killLongRunning", "admin", "$cmd", "ops", opids)
+		return []any{}, nil
+	}
+
+	ctx, cancel := c.operationContext()
+	defer cancel()
+
+	killed := make([]any, 0, len(ops))
+	for _, op := range ops {
+		// opid is a number on mongod and a "shard:opid" string on mongos.
+		opid, ok := op["opid"]
+		if !ok {
+			continue
+		}
+		if err := c.killOp(ctx, opid); err != nil {
+			log.Printf("Error while killing operation %v: %v", opid, err)
+			return killed, err
+		}
+		killed = append(killed, opid)
+	}
+	return killed, nil
+}
+
+func (c *Client) killOp(ctx context.Context, opid any) error {
+	cmd := bson.D{{Key: "killOp", Value: 1}, {Key: "op", Value: opid}}
+	return c.client.Database("admin").RunCommand(ctx, cmd).Err()
+}
+
+// ValidateCollection runs the validate command on a collection and returns
+// its report. The report's valid field tells whether the collection and its
+// indexes are consistent. A full validation checks every document and index
+// entry and takes an exclusive lock on the collection while it runs.
+func (c *Client) ValidateCollection(database string, collection string, full bool) (bson.M, error) {
+	ctx, cancel := c.operationContext()
+	defer cancel()
+
+	cmd := bson.D{
+		{Key: "validate", Value: collection},
+		{Key: "full", Value: full},
+	}
+	var report bson.M
+	err := c.client.Database(database).RunCommand(ctx, cmd).Decode(&report)
+	if err != nil {
+		log.Printf("Error while validating collection: %v", err)
+		return nil, err
+	}
+	return report, nil
+}
+
+// ValidateAgainstSchema reports whether doc passes the validator of the
+// collection, e.g. a $jsonSchema, without writing it, by matching it against
+// the validator in a $documents aggregation. Documents are always valid for a
+// collection without a validator. It requires MongoDB 5.1 or later.
+func (c *Client) ValidateAgainstSchema(database string, collection string, doc any) (bool, error) {
+	ctx, cancel := c.operationContext()
+	defer cancel()
+
+	db := c.client.Database(database)
+	specs, err := db.ListCollectionSpecifications(ctx, bson.D{{Key: "name", Value: collection}})
+	if err != nil {
+		log.Printf("Error while reading collection options: %v", err)
+		return false, err
+	}
+	if len(specs) == 0 {
+		return false, fmt.Errorf("collection %s.%s does not exist", database, collection)
+	}
+	validator, err := specs[0].Options.LookupErr("validator")
+	if err != nil {
+		return true, nil
+	}
+
+	pipeline := bson.A{
+		bson.D{{Key: "$documents", Value: bson.A{doc}}},
+		bson.D{{Key: "$match", Value: validator}},
+		bson.D{{Key: "$count", Value: "n"}},
+	}
+	cur, err := db.Aggregate(ctx, pipeline, c.aggregateOptions())
+	if err != nil {
+		log.Printf("Error while validating document: %v", err)
+		return false, err
+	}
+	var matched []bson.M
+	if err := cur.All(ctx, &matched); err != nil {
+		log.Printf(errDecodingDocuments, err)
+		return false, err
+	}
+	return len(matched) > 0, nil
+}
+
+// ClusterTime returns the operation time the server reports for a ping, i.e.
+// the cluster time of its latest applied operation. It is an opaque token for
+// options such as a change stream's startAtOperationTime. Only replica set
+// members and mongos report an operation time.
+func (c *Client) ClusterTime() (primitive.Timestamp, error) {
+	ctx, cancel := c.operationContext()
+	defer cancel()
+
+	var reply struct {
+		OperationTime primitive.Timestamp `bson:"operationTime"`
+	}
+	cmd := bson.D{{Key: "ping", Value: 1}}
+	if err := c.client.Database("admin").RunCommand(ctx, cmd).Decode(&reply); err != nil {
+		log.Printf("Error while reading cluster time: %v", err)
+		return primitive.Timestamp{}, err
+	}
+	if reply.OperationTime.IsZero() {
+		return primitive.Timestamp{}, fmt.Errorf("server reported no operation time, it must be a replica set member or mongos")
+	}
+	return reply.OperationTime, nil
+}
+
+// QueryStats is the summary of a query's execution statistics that matters
+// for spotting index regressions.
+type QueryStats struct {
+	DocsExamined    int64 `js:"docsExamined"`
+	KeysExamined    int64 `js:"keysExamined"`
+	NReturned       int64 `js:"nReturned"`
+	ExecutionTimeMS int64 `js:"executionTimeMS"`
+}
+
+// QueryStats explains a find with filter at executionStats verbosity, which
+// runs the query to completion, and returns a summary of its statistics. A
+// query using a selective index examines about as many keys and documents as
+// it returns.
+func (c *Client) QueryStats(database string, collection string, filter any) (*QueryStats, error) {
+	filter = c.coerceFilter(filter)
+	ctx, cancel := c.operationContext()
+	defer cancel()
+
+	if filter == nil {
+		filter = bson.D{}
+	}
+	cmd := bson.D{
+		{Key: "explain", Value: bson.D{
+			{Key: "find", Value: collection},
+			{Key: "filter", Value: filter},
+		}},
+		{Key: "verbosity", Value: "executionStats"},
+	}
+	var reply struct {
+		ExecutionStats struct {
+			NReturned           int64 `bson:"nReturned"`
+			ExecutionTimeMillis int64 `bson:"executionTimeMillis"`
+			TotalKeysExamined   int64 `bson:"totalKeysExamined"`
+			TotalDocsExamined   int64 `bson:"totalDocsExamined"`
+		} `bson:"executionStats"`
+	}
+	if err := c.client.Database(database).RunCommand(ctx, cmd).Decode(&reply); err != nil {
+		log.Printf("Error while explaining query: %v", err)
+		return nil, err
+	}
+	stats := reply.ExecutionStats
+	return &QueryStats{
+		DocsExamined:    stats.TotalDocsExamined,
+		KeysExamined:    stats.TotalKeysExamined,
+		NReturned:       stats.NReturned,
+		ExecutionTimeMS: stats.ExecutionTimeMillis,
+	}, nil
+}
+
+// ReplicationLag returns how far, in milliseconds, the most lagging secondary
+// is behind the primary, comparing the optimes that replSetGetStatus reports
+// for them. Since optimes only advance with writes, the lag is only meaningful
+// while the primary is being written to.
+func (c *Client) ReplicationLag() (int64, error) {
+	ctx, cancel := c.operationContext()
+	defer cancel()
+
+	var status struct {
+		Members []struct {
+			State      int       `bson:"state"`
+			OptimeDate time.Time `bson:"optimeDate"`
+		} `bson:"members"`
+	}
+	cmd := bson.D{{Key: "replSetGetStatus", Value: 1}}
+	if err := c.client.Database("admin").RunCommand(ctx, cmd).Decode(&status); err != nil {
+		log.Printf("Error while reading replica set status: %v", err)
+		return 0, err
+	}
+
+	const (
+		statePrimary   = 1
+		stateSecondary = 2
+	)
+	var primary time.Time
+	var secondaries []time.Time
+	for _, member := range status.Members {
+		switch member.State {
+		case statePrimary:
+			primary = member.OptimeDate
+		case stateSecondary:
+			secondaries = append(secondaries, member.OptimeDate)
+		}
+	}
+	if primary.IsZero() {
+		return 0, fmt.Errorf("replica set has no primary")
+	}
+
+	var lag time.Duration
+	for _, optime := range secondaries {
+		lag = max(lag, primary.Sub(optime))
+	}
+	return lag.Milliseconds(), nil
+}
+
+// ShardDistribution returns the number of documents of a sharded collection
+// held by each shard, keyed by shard name, using the $collStats stage with a
+// count through mongos. Counts include orphaned documents that a migration
+// left behind and that are not yet cleaned up.
+func (c *Client) ShardDistribution(database string, collection string) (map[string]int64, error) {
+	ctx, cancel := c.operationContext()
+	defer cancel()
+
+	pipeline := bson.A{
+		bson.D{{Key: "$collStats", Value: bson.D{{Key: "count", Value: bson.D{}}}}},
+	}
+	col := c.client.Database(database).Collection(collection)
+	cur, err := col.Aggregate(ctx, pipeline, c.aggregateOptions())
+	if err != nil {
+		log.Printf("Error while reading shard distribution: %v", err)
+		return nil, err
+	}
+	var stats []struct {
+		Shard string `bson:"shard"`
+		Count int64  `bson:"count"`
+	}
+	if err = cur.All(ctx, &stats); err != nil {
+		log.Printf(errDecodingDocuments, err)
+		return nil, err
+	}
+
+	distribution := make(map[string]int64, len(stats))
+	for _, s := range stats {
+		if s.Shard == "" {
+			return nil, fmt.Errorf("collection statistics have no shard, the client must be connected to mongos")
+		}
+		distribution[s.Shard] += s.Count
+	}
+	return distribution, nil
+}
+
+// WriteStats holds the WiredTiger counters of a collection that relate
+// logical writes to the bytes the storage engine wrote, summed over shards.
+// The counters are cumulative since the collection's table was opened, so
+// write amplification is the growth of BytesWritten over a test divided by
+// the growth of Size or by the bytes the test inserted.
+type WriteStats struct {
+	// Size is the uncompressed size of the collection's documents.
+	Size int64 `js:"size"`
+	// BytesWritten counts the bytes the block manager wrote to disk.
+	BytesWritten int64 `js:"bytesWritten"`
+	// CacheBytesWritten counts the bytes written from the cache, before
+	// compression.
+	CacheBytesWritten int64 `js:"cacheBytesWritten"`
+	// PagesEvicted counts the modified and unmodified pages evicted from the
+	// cache.
+	PagesEvicted int64 `js:"pagesEvicted"`
+}
+
+// WriteStats reads the WiredTiger statistics of a collection with the
+// $collStats stage, the replacement of the collStats command, and returns
+// its write counters. It fails on storage engines other than WiredTiger.
+func (c *Client) WriteStats(database string, collection string) (*WriteStats, error) {
+	ctx, cancel := c.operationContext()
+	defer cancel()
+
+	pipeline := bson.A{
+		bson.D{{Key: "$collStats", Value: bson.D{{Key: "storageStats", Value: bson.D{}}}}},
+	}
+	col := c.client.Database(database).Collection(collection)
+	cur, err := col.Aggregate(ctx, pipeline, c.aggregateOptions())
+	if err != nil {
+		log.Printf("Error while reading collection statistics: %v", err)
+		return nil, err
+	}
+	var stats []struct {
+		StorageStats struct {
+			Size       int64  `bson:"size"`
+			WiredTiger bson.M `bson:"wiredTiger"`
+		} `bson:"storageStats"`
+	}
+	if err = cur.All(ctx, &stats); err != nil {
+		log.Printf(errDecodingDocuments, err)
+		return nil, err
+	}
+
+	result := &WriteStats{}
+	for _, s := range stats {
+		wt := s.StorageStats.WiredTiger
+		if wt == nil {
+			return nil, fmt.Errorf("collection statistics have no WiredTiger section, the storage engine must be WiredTiger")
+		}
+		result.Size += s.StorageStats.Size
+		result.BytesWritten += wiredTigerStat(wt, "block-manager", "bytes written")
+		result.CacheBytesWritten += wiredTigerStat(wt, "cache", "bytes written from cache")
+		result.PagesEvicted += wiredTigerStat(wt, "cache", "modified pages evicted") +
+			wiredTigerStat(wt, "cache", "unmodified pages evicted")
+	}
+	return result, nil
+}
+
+// wiredTigerStat returns the counter called name in a WiredTiger statistics
+// section, or 0 if it is missing, as counters vary across server versions.
+func wiredTigerStat(wt bson.M, section string, name string) int64 {
+	stats, ok := wt[section].(bson.M)
+	if !ok {
+		return 0
+	}
+	switch v := stats[name].(type) {
+	case int32:
+		return int64(v)
+	case int64:
+		return v
+	case float64:
+		return int64(v)
+	default:
+		return 0
+	}
+}
+
+// WireVersionInfo describes the wire protocol versions in play: the range the
+// driver supports, the range the server supports and the version they use,
+// the highest one both support.
+type WireVersionInfo struct {
+	DriverMin  int32 `js:"driverMin"`
+	DriverMax  int32 `js:"driverMax"`
+	ServerMin  int32 `js:"serverMin"`
+	ServerMax  int32 `js:"serverMax"`
+	Negotiated int32 `js:"negotiated"`
+}
+
+// WireVersion reads the server's wire version range from a hello command and
+// returns it alongside the driver's.
+func (c *Client) WireVersion() (*WireVersionInfo, error) {
+	ctx, cancel := c.operationContext()
+	defer cancel()
+
+	var hello struct {
+		MinWireVersion int32 `bson:"minWireVersion"`
+		MaxWireVersion int32 `bson:"maxWireVersion"`
+	}
+	cmd := bson.D{{Key: "hello", Value: 1}}
+	if err := c.client.Database("admin").RunCommand(ctx, cmd).Decode(&hello); err != nil {
+		log.Printf("Error while reading wire version: %v", err)
+		return nil, err
+	}
+	return &WireVersionInfo{
+		DriverMin:  topology.SupportedWireVersions.Min,
+		DriverMax:  topology.SupportedWireVersions.Max,
+		ServerMin:  hello.MinWireVersion,
+		ServerMax:  hello.MaxWireVersion,
+		Negotiated: min(topology.SupportedWireVersions.Max, hello.MaxWireVersion),
+	}, nil
+}
+
+// IndexUsage is how often an index was used by a single server since the
+// given time, usually its last restart or the index creation.
+type IndexUsage struct {
+	Name  string    `js:"name"`
+	Host  string    `js:"host"`
+	Ops   int64     `js:"ops"`
+	Since time.Time `js:"since"`
+}
+
+// IndexStats returns the usage of every index of a collection, from the
+// $indexStats aggregation stage. Each server keeps its own counters, so on a
+// sharded cluster there is one entry per index and shard, and counters only
+// reflect the server they were read from.
+func (c *Client) IndexStats(database string, collection string) ([]IndexUsage, error) {
+	ctx, cancel := c.operationContext()
+	defer cancel()
+
+	pipeline := bson.A{bson.D{{Key: "$indexStats", Value: bson.D{}}}}
+	col := c.client.Database(database).Collection(collection)
+	cur, err := col.Aggregate(ctx, pipeline, c.aggregateOptions())
+	if err != nil {
+		log.Printf("Error while reading index statistics: %v", err)
+		return nil, err
+	}
+	var stats []struct {
+		Name     string `bson:"name"`
+		Host     string `bson:"host"`
+		Accesses struct {
+			Ops   int64     `bson:"ops"`
+			Since time.Time `bson:"since"`
+		} `bson:"accesses"`
+	}
+	if err = cur.All(ctx, &stats); err != nil {
+		log.Printf(errDecodingDocuments, err)
+		return nil, err
+	}
+
+	usage := make([]IndexUsage, len(stats))
+	for i, s := range stats {
+		usage[i] = IndexUsage{Name: s.Name, Host: s.Host, Ops: s.Accesses.Ops, Since: s.Accesses.Since}
+	}
+	return usage, nil
+}
+
+// BalancerState is the state of the balancer of a sharded cluster. Mode is
+// "full" while the balancer is enabled and "off" otherwise, and InRound tells
+// whether a balancing round is running.
+type BalancerState struct {
+	Enabled bool   `js:"enabled"`
+	Mode    string `js:"mode"`
+	InRound bool   `js:"inRound"`
+}
+
+// GetBalancerState returns the balancer state of the sharded cluster the
+// client is connected to through mongos.
+func (c *Client) GetBalancerState() (*BalancerState, error) {
+	ctx, cancel := c.operationContext()
+	defer cancel()
+
+	var reply struct {
+		Mode            string `bson:"mode"`
+		InBalancerRound bool   `bson:"inBalancerRound"`
+	}
+	cmd := bson.D{{Key: "balancerStatus", Value: 1}}
+	if err := c.client.Database("admin").RunCommand(ctx, cmd).Decode(&reply); err != nil {
+		log.Printf("Error while reading balancer state: %v", err)
+		return nil, err
+	}
+	return &BalancerState{Enabled: reply.Mode != "off", Mode: reply.Mode, InRound: reply.InBalancerRound}, nil
+}
+
+// SetBalancerState enables or disables the balancer of the sharded cluster.
+// Disabling it waits for a running balancing round to finish, so no chunk
+// migration is in progress once it returns.
+func (c *Client) SetBalancerState(enabled bool) error {
+	command := "balancerStop"
+	if enabled {
+		command = "balancerStart"
+	}
+	if c.dryRun(command, "admin", "$cmd") {
+		return nil
+	}
+
+	ctx, cancel := c.operationContext()
+	defer cancel()
+
+	if err := c.client.Database("admin").RunCommand(ctx, bson.D{{Key: command, Value: 1}}).Err(); err != nil {
+		log.Printf("Error while setting balancer state: %v", err)
+		return err
+	}
+	return nil
+}
+
+// GetFCV returns the featureCompatibilityVersion of the deployment, e.g.
+// "7.0". During an upgrade or downgrade the server reports the version it
+// is transitioning from.
+func (c *Client) GetFCV() (string, error) {
+	ctx, cancel := c.operationContext()
+	defer cancel()
+
+	var reply struct {
+		FCV struct {
+			Version string `bson:"version"`
+		} `bson:"featureCompatibilityVersion"`
+	}
+	cmd := bson.D{{Key: "getParameter", Value: 1}, {Key: "featureCompatibilityVersion", Value: 1}}
+	if err := c.client.Database("admin").RunCommand(ctx, cmd).Decode(&reply); err != nil {
+		log.Printf("Error while reading feature compatibility version: %v", err)
+		return "", err
+	}
+	return reply.FCV.Version, nil
+}
+
+// SetFCV sets the featureCompatibilityVersion of the deployment to version,
+// e.g. "7.0". MongoDB 7.0 and later require the change to be confirmed, which
+// SetFCV does on the caller's behalf, so it must only be used against test
+// deployments.
+func (c *Client) SetFCV(version string) error {
+	if version == "" {
+		return fmt.Errorf("version cannot be empty")
+	}
+	if c.dryRun("setFeatureCompatibilityVersion", "admin", "$cmd", "version", version) {
+		return nil
+	}
+
+	ctx, cancel := c.operationContext()
+	defer cancel()
+
+	serverVersion, err := c.serverVersion(ctx)
+	if err != nil {
+		log.Printf("Error while reading server version: %v", err)
+		return err
+	}
+	cmd := bson.D{{Key: "setFeatureCompatibilityVersion", Value: version}}
+	if len(serverVersion) > 0 && serverVersion[0] >= 7 {
+		cmd = append(cmd, bson.E{Key: "confirm", Value: true})
+	}
+	if err := c.client.Database("admin").RunCommand(ctx, cmd).Err(); err != nil {
+		log.Printf("Error while setting feature compatibility version: %v", err)
+		return err
+	}
+	return nil
+}
+
+// serverVersion returns the server version as reported by buildInfo, e.g.
+// [7 0 2 0].
+func (c *Client) serverVersion(ctx context.Context) ([]int32, error) {
+	var info struct {
+		VersionArray []int32 `bson:"versionArray"`
+	}
+	cmd := bson.D{{Key: "buildInfo", Value: 1}}
+	if err := c.client.Database("admin").RunCommand(ctx, cmd).Decode(&info); err != nil {
+		return nil, err
+	}
+	return info.VersionArray, nil
+}
+
+func formatVersion(version []int32) string {
+	parts := make([]string, len(version))
+	for i, v := range version {
+		parts[i] = fmt.Sprint(v)
+	}
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,133 @@
+package xk6_mongo
+
+import (
+	"fmt"
+
+	"github.com/grafana/sobek"
+	"go.k6.io/k6/js/promises"
+)
+
+// promise runs fn on its own goroutine and returns a promise that is settled
+// with fn's result on the VU's event loop. The event loop stays free while the
+// driver call is in flight, so long-running operations no longer stall the
+// other asynchronous work of the VU.
+func (c *Client) promise(fn func() (any, error)) (*sobek.Promise, error) {
+	if c.vu == nil {
+		return nil, fmt.Errorf("async operations require running under k6")
+	}
+	p, resolve, reject := promises.New(c.vu)
+	go func() {
+		result, err := fn()
+		if err != nil {
+			reject(err)
+			return
+		}
+		resolve(result)
+	}()
+	return p, nil
+}
+
+// AggregateAsync is Aggregate returning a promise of the results.
+func (c *Client) AggregateAsync(database string, collection string, pipeline any, callOpts ...any) (*sobek.Promise, error) {
+	return c.promise(func() (any, error) {
+		return c.Aggregate(database, collection, pipeline, callOpts...)
+	})
+}
+
+// FindAsync is Find returning a promise of the documents.
+func (c *Client) FindAsync(database string, collection string, filter any, sort any, limit int64) (*sobek.Promise, error) {
+	return c.promise(func() (any, error) {
+		return c.Find(database, collection, filter, sort, limit)
+	})
+}
+
+// FindWithOptionsAsync is FindWithOptions returning a promise of the documents.
+func (c *Client) FindWithOptionsAsync(database string, collection string, filter any, opts any) (*sobek.Promise, error) {
+	return c.promise(func() (any, error) {
+		return c.FindWithOptions(database, collection, filter, opts)
+	})
+}
+
+// FindOneAsync is FindOne returning a promise of the document.
+func (c *Client) FindOneAsync(database string, collection string, filter any) (*sobek.Promise, error) {
+	return c.promise(func() (any, error) {
+		return c.FindOne(database, collection, filter)
+	})
+}
+
+// CountDocumentsAsync is CountDocuments returning a promise of the count.
+func (c *Client) CountDocumentsAsync(database string, collection string, filter any) (*sobek.Promise, error) {
+	return c.promise(func() (any, error) {
+		return c.CountDocuments(database, collection, filter)
+	})
+}
+
+// DistinctAsync is Distinct returning a promise of the values.
+func (c *Client) DistinctAsync(database string, collection string, field string, filter any) (*sobek.Promise, error) {
+	return c.promise(func() (any, error) {
+		return c.Distinct(database, collection, field, filter)
+	})
+}
+
+// InsertAsync is Insert returning a promise that resolves once the document
+// is inserted.
+func (c *Client) InsertAsync(database string, collection string, doc any, callOpts ...any) (*sobek.Promise, error) {
+	return c.promise(func() (any, error) {
+		return nil, c.Insert(database, collection, doc, callOpts...)
+	})
+}
+
+// InsertManyAsync is InsertMany returning a promise that resolves once the
+// documents are inserted.
+func (c *Client) InsertManyAsync(database string, collection string, docs []any, callOpts ...any) (*sobek.Promise, error) {
+	return c.promise(func() (any, error) {
+		return nil, c.InsertMany(database, collection, docs, callOpts...)
+	})
+}
+
+// UpsertAsync is Upsert returning a promise of the upsert result.
+func (c *Client) UpsertAsync(database string, collection string, filter any, upsert any, callOpts ...any) (*sobek.Promise, error) {
+	return c.promise(func() (any, error) {
+		return c.Upsert(database, collection, filter, upsert, callOpts...)
+	})
+}
+
+// UpdateOneAsync is UpdateOne returning a promise that resolves once the
+// document is updated.
+func (c *Client) UpdateOneAsync(database string, collection string, filter any, data any, callOpts ...any) (*sobek.Promise, error) {
+	return c.promise(func() (any, error) {
+		return nil, c.UpdateOne(database, collection, filter, data, callOpts...)
+	})
+}
+
+// UpdateManyAsync is UpdateMany returning a promise that resolves once the
+// documents are updated.
+func (c *Client) UpdateManyAsync(database string, collection string, filter any, data any, callOpts ...any) (*sobek.Promise, error) {
+	return c.promise(func() (any, error) {
+		return nil, c.UpdateMany(database, collection, filter, data, callOpts...)
+	})
+}
+
+// FindOneAndUpdateAsync is FindOneAndUpdate returning a promise of the
+// updated document.
+func (c *Client) FindOneAndUpdateAsync(database string, collection string, filter any, update any, callOpts ...any) (*sobek.Promise, error) {
+	return c.promise(func() (any, error) {
+		return c.FindOneAndUpdate(database, collection, filter, update, callOpts...)
+	})
+}
+
+// DeleteOneAsync is DeleteOne returning a promise that resolves once the
+// document is deleted.
+func (c *Client) DeleteOneAsync(database string, collection string, filter any, callOpts ...any) (*sobek.Promise, error) {
+	return c.promise(func() (any, error) {
+		return nil, c.DeleteOne(database, collection, filter, callOpts...)
+	})
+}
+
+// DeleteManyAsync is DeleteMany returning a promise that resolves once the
+// documents are deleted.
+func (c *Client) DeleteManyAsync(database string, collection string, filter any, callOpts ...any) (*sobek.Promise, error) {
+	return c.promise(func() (any, error) {
+		return nil, c.DeleteMany(database, collection, filter, callOpts...)
+	})
+}
@@ -0,0 +1,204 @@
+package xk6_mongo
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// DeleteManyInBatches deletes the documents matching filter in batches of at
+// most batchSize documents, walking the collection in _id order, which bounds
+// the work and lock impact of each delete. After every batch onProgress, if
+// given, is called with the number of documents deleted so far. The total is
+// returned. Each batch is sent like DeleteMany, with the client's comment,
+// write retry and error classification.
+func (c *Client) DeleteManyInBatches(database string, collection string, filter any, batchSize int64, onProgress func(int64) error) (int64, error) {
+	filter = c.coerceFilter(filter)
+	if c.dryRun("deleteMany", database, collection, "filter", filter) {
+		return 0, nil
+	}
+
+	ctx, cancel := c.operationContext()
+	defer cancel()
+
+	db := c.client.Database(database)
+	col := db.Collection(collection)
+	total, err := c.forEachIDBatch(ctx, col, filter, batchSize, func(ids bson.A) (int64, error) {
+		res, err := withWriteRetry(ctx, c, func() (*mongo.DeleteResult, error) {
+			return col.DeleteMany(ctx, bson.D{{Key: "_id", Value: bson.D{{Key: "$in", Value: ids}}}}, options.Delete().SetComment(c.commentOption()))
+		})
+		err = classifyWriteError(err)
+		if err != nil {
+			return 0, err
+		}
+		return res.DeletedCount, nil
+	}, onProgress)
+	if err != nil {
+		log.Printf("Error while deleting the documents in batches: %v", err)
+		return total, err
+	}
+	return total, nil
+}
+
+// UpdateManyInBatches is the batched counterpart of UpdateMany, see
+// DeleteManyInBatches. The returned total counts modified documents.
+func (c *Client) UpdateManyInBatches(database string, collection string, filter any, data any, batchSize int64, onProgress func(int64) error) (int64, error) {
+	filter = c.coerceFilter(filter)
+	if c.dryRun("updateMany", database, collection, "filter", filter, "update", data) {
+		return 0, nil
+	}
+
+	ctx, cancel := c.operationContext()
+	defer cancel()
+
+	update, err := prepareUpdateDocument(data)
+	if err != nil {
+		log.Printf("Error while preparing update document: %v", err)
+		return 0, err
+	}
+
+	db := c.client.Database(database)
+	col := db.Collection(collection)
+	total, err := c.forEachIDBatch(ctx, col, filter, batchSize, func(ids bson.A) (int64, error) {
+		res, err := withWriteRetry(ctx, c, func() (*mongo.UpdateResult, error) {
+			return col.UpdateMany(ctx, bson.D{{Key: "_id", Value: bson.D{{Key: "$in", Value: ids}}}}, update, options.Update().SetComment(c.commentOption()))
+		})
+		err = classifyWriteError(err)
+		if err != nil {
+			return 0, err
+		}
+		return res.ModifiedCount, nil
+	}, onProgress)
+	if err != nil {
+		log.Printf("Error while updating the documents in batches: %v", err)
+		return total, err
+	}
+	return total, nil
+}
+
+// forEachIDBatch fetches the _id of the documents matching filter in
+// ascending batches of batchSize and hands every batch to apply, reporting
+// the running total of apply's counts to onProgress.
+func (c *Client) forEachIDBatch(ctx context.Context, col *mongo.Collection, filter any, batchSize int64, apply func(bson.A) (int64, error), onProgress func(int64) error) (int64, error) {
+	if batchSize <= 0 {
+		return 0, fmt.Errorf("batch size must be positive, got %d", batchSize)
+	}
+	if filter == nil {
+		filter = bson.D{}
+	}
+
+	opts := options.Find().
+		SetProjection(bson.D{{Key: "_id", Value: 1}}).
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetLimit(batchSize)
+
+	var total int64
+	var last any
+	for {
+		batchFilter := filter
+		if last != nil {
+			batchFilter = bson.D{{Key: "$and", Value: bson.A{
+				filter,
+				bson.D{{Key: "_id", Value: bson.D{{Key: "$gt", Value: last}}}},
+			}}}
+		}
+
+		cur, err := withRetry(ctx, c, func() (*mongo.Cursor, error) { return col.Find(ctx, batchFilter, c.withFindComment(opts)) })
+		if err != nil {
+			return total, err
+		}
+		var docs []struct {
+			ID any `bson:"_id"`
+		}
+		if err := cur.All(ctx, &docs); err != nil {
+			return total, err
+		}
+		if len(docs) == 0 {
+			return total, nil
+		}
+
+		ids := make(bson.A, len(docs))
+		for i, doc := range docs {
+			ids[i] = doc.ID
+		}
+		n, err := apply(ids)
+		total += n
+		if err != nil {
+			return total, err
+		}
+		if onProgress != nil {
+			if err := onProgress(total); err != nil {
+				return total, err
+			}
+		}
+		if int64(len(docs)) < batchSize {
+			return total, nil
+		}
+		last = ids[len(ids)-1]
+	}
+}
+
+// DeleteLimited deletes at most limit of the documents matching filter,
+// lowest _id first, and returns the number deleted. MongoDB has no limit on
+// deleteMany, so the _id of the first limit matches are fetched and deleted
+// with a single deleteMany, which also re-checks filter in case a document
+// changed in between.
+func (c *Client) DeleteLimited(database string, collection string, filter any, limit int64) (int64, error) {
+	filter = c.coerceFilter(filter)
+	if c.dryRun("deleteLimited", database, collection, "filter", filter, "limit", limit) {
+		return 0, nil
+	}
+
+	ctx, cancel := c.operationContext()
+	defer cancel()
+
+	if limit <= 0 {
+		return 0, fmt.Errorf("limit must be positive, got %d", limit)
+	}
+	if filter == nil {
+		filter = bson.D{}
+	}
+
+	col := c.client.Database(database).Collection(collection)
+	opts := options.Find().
+		SetProjection(bson.D{{Key: "_id", Value: 1}}).
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetLimit(limit)
+	cur, err := withRetry(ctx, c, func() (*mongo.Cursor, error) { return col.Find(ctx, filter, c.withFindComment(opts)) })
+	if err != nil {
+		log.Printf("Error while finding documents to delete: %v", err)
+		return 0, err
+	}
+	var docs []struct {
+		ID any `bson:"_id"`
+	}
+	if err := cur.All(ctx, &docs); err != nil {
+		log.Printf(errDecodingDocuments, err)
+		return 0, err
+	}
+	if len(docs) == 0 {
+		return 0, nil
+	}
+	ids := make(bson.A, len(docs))
+	for i, doc := range docs {
+		ids[i] = doc.ID
+	}
+
+	idFilter := bson.D{{Key: "$and", Value: bson.A{
+		filter,
+		bson.D{{Key: "_id", Value: bson.D{{Key: "$in", Value: ids}}}},
+	}}}
+	res, err := withWriteRetry(ctx, c, func() (*mongo.DeleteResult, error) {
+		return col.DeleteMany(ctx, idFilter, options.Delete().SetComment(c.commentOption()))
+	})
+	err = classifyWriteError(err)
+	if err != nil {
+		log.Printf("Error while deleting documents: %v", err)
+		return 0, err
+	}
+	return res.DeletedCount, nil
+}
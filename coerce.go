@@ -0,0 +1,132 @@
+package xk6_mongo
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SetCoerceObjectIds makes every method taking a filter convert an _id given
+// as a 24 character hex string to an ObjectId, so that ids read back from a
+// JSON response or a CSV file match. The conversion applies to the _id value
+// itself, to the values of its $eq, $ne, $in and $nin operators and to the
+// clauses of top-level $and, $or and $nor operators. The caller's filter is
+// left unchanged.
+func (c *Client) SetCoerceObjectIds(enabled bool) {
+	c.coerceObjectIds = enabled
+}
+
+// coerceFilter returns filter with its _id hex strings converted to ObjectIds
+// and its numeric Extended JSON wrappers converted to numbers if the client
+// coerces them, see SetCoerceObjectIds and SetCoerceNumbers.
+func (c *Client) coerceFilter(filter any) any {
+	if c.coerceNumbers {
+		filter = coerceNumberWrappers(filter)
+	}
+	if c.coerceObjectIds {
+		filter = coerceIdFilter(filter)
+	}
+	return filter
+}
+
+func coerceIdFilter(filter any) any {
+	switch f := filter.(type) {
+	case map[string]any:
+		return coerceIdMap(f)
+	case bson.M:
+		return bson.M(coerceIdMap(f))
+	case bson.D:
+		out := make(bson.D, len(f))
+		for i, elem := range f {
+			out[i] = bson.E{Key: elem.Key, Value: coerceIdField(elem.Key, elem.Value)}
+		}
+		return out
+	default:
+		return filter
+	}
+}
+
+func coerceIdMap(filter map[string]any) map[string]any {
+	out := make(map[string]any, len(filter))
+	for key, value := range filter {
+		out[key] = coerceIdField(key, value)
+	}
+	return out
+}
+
+func coerceIdField(key string, value any) any {
+	switch key {
+	case "_id":
+		return coerceIdCondition(value)
+	case "$and", "$or", "$nor":
+		return coerceArray(value, coerceIdFilter)
+	default:
+		return value
+	}
+}
+
+// coerceIdCondition converts the condition on _id, either a value or an
+// operator document.
+func coerceIdCondition(cond any) any {
+	coerceOperator := func(op string, value any) any {
+		switch op {
+		case "$eq", "$ne":
+			return coerceObjectId(value)
+		case "$in", "$nin":
+			return coerceArray(value, coerceObjectId)
+		default:
+			return value
+		}
+	}
+	switch c := cond.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(c))
+		for op, value := range c {
+			out[op] = coerceOperator(op, value)
+		}
+		return out
+	case bson.M:
+		out := make(bson.M, len(c))
+		for op, value := range c {
+			out[op] = coerceOperator(op, value)
+		}
+		return out
+	case bson.D:
+		out := make(bson.D, len(c))
+		for i, elem := range c {
+			out[i] = bson.E{Key: elem.Key, Value: coerceOperator(elem.Key, elem.Value)}
+		}
+		return out
+	default:
+		return coerceObjectId(cond)
+	}
+}
+
+func coerceArray(value any, coerce func(any) any) any {
+	var items []any
+	switch v := value.(type) {
+	case []any:
+		items = v
+	case bson.A:
+		items = v
+	default:
+		return value
+	}
+	out := make([]any, len(items))
+	for i, item := range items {
+		out[i] = coerce(item)
+	}
+	return out
+}
+
+// coerceObjectId returns value as an ObjectId if it is a valid hex string.
+func coerceObjectId(value any) any {
+	s, ok := value.(string)
+	if !ok || len(s) != 24 {
+		return value
+	}
+	oid, err := primitive.ObjectIDFromHex(s)
+	if err != nil {
+		return value
+	}
+	return oid
+}
@@ -0,0 +1,79 @@
+package xk6_mongo
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCoerceFilter(t *testing.T) {
+	hex := "64b7f3a2c9e77a1d2f3b4c5d"
+	oid, _ := primitive.ObjectIDFromHex(hex)
+
+	tests := []struct {
+		name   string
+		filter any
+		want   any
+	}{
+		{
+			name:   "plain id",
+			filter: map[string]any{"_id": hex, "name": hex},
+			want:   map[string]any{"_id": oid, "name": hex},
+		},
+		{
+			name:   "not an object id",
+			filter: map[string]any{"_id": "order-42"},
+			want:   map[string]any{"_id": "order-42"},
+		},
+		{
+			name:   "operators",
+			filter: bson.M{"_id": bson.M{"$in": []any{hex, "x"}, "$gt": hex}},
+			want:   bson.M{"_id": bson.M{"$in": []any{oid, "x"}, "$gt": hex}},
+		},
+		{
+			name:   "logical operators",
+			filter: bson.D{{Key: "$or", Value: bson.A{map[string]any{"_id": hex}, map[string]any{"sku": hex}}}},
+			want:   bson.D{{Key: "$or", Value: []any{map[string]any{"_id": oid}, map[string]any{"sku": hex}}}},
+		},
+	}
+
+	c := &Client{}
+	c.SetCoerceObjectIds(true)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := c.coerceFilter(tt.filter); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("coerceFilter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	filter := map[string]any{"_id": hex}
+	c.SetCoerceObjectIds(false)
+	if got := c.coerceFilter(filter); !reflect.DeepEqual(got, filter) {
+		t.Errorf("coerceFilter() without coercion = %v, want %v", got, filter)
+	}
+}
+
+func TestCoerceNumbers(t *testing.T) {
+	dec, _ := primitive.ParseDecimal128("19.99")
+
+	c := &Client{}
+	c.SetCoerceNumbers(true)
+	filter := map[string]any{
+		"count": map[string]any{"$numberLong": "9007199254740993"},
+		"price": map[string]any{"$gte": map[string]any{"$numberDecimal": "19.99"}},
+		"qty":   map[string]any{"$in": []any{map[string]any{"$numberInt": "3"}, 4.0}},
+		"bad":   map[string]any{"$numberLong": "x"},
+	}
+	want := map[string]any{
+		"count": int64(9007199254740993),
+		"price": map[string]any{"$gte": dec},
+		"qty":   map[string]any{"$in": []any{int32(3), 4.0}},
+		"bad":   map[string]any{"$numberLong": "x"},
+	}
+	if got := c.coerceFilter(filter); !reflect.DeepEqual(got, want) {
+		t.Errorf("coerceFilter() = %v, want %v", got, want)
+	}
+}
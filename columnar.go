@@ -0,0 +1,124 @@
+package xk6_mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// mapRegistry decodes embedded documents held in an any as bson.M rather
+// than bson.D, so that single values reach scripts as objects, the way
+// documents returned by Find do.
+var mapRegistry = func() *bsoncodec.Registry {
+	reg := bson.NewRegistry()
+	reg.RegisterTypeMapEntry(bson.TypeEmbeddedDocument, reflect.TypeOf(bson.M{}))
+	return reg
+}()
+
+// ColumnarResult holds query results as one array of values per row, in the
+// order of Columns, which avoids building an object per document in the VU.
+type ColumnarResult struct {
+	Columns []string `js:"columns"`
+	Rows    [][]any  `js:"rows"`
+}
+
+// FindColumnar is FindWithOptions returning the given columns, which may be
+// dotted paths, as a ColumnarResult. Unless opts sets a projection, only the
+// requested columns are fetched.
+func (c *Client) FindColumnar(database string, collection string, filter any, columns []string, opts any) (*ColumnarResult, error) {
+	filter = c.coerceFilter(filter)
+	call, opts, err := c.withCallTimeout(opts)
+	if err != nil {
+		log.Printf("Error while preparing find options: %v", err)
+		return nil, err
+	}
+	ctx, cancel := call.operationContext()
+	defer cancel()
+
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("columns cannot be empty")
+	}
+	findOptions, readPref, err := prepareFindReadOptions(opts)
+	if err != nil {
+		log.Printf("Error while preparing find options: %v", err)
+		return nil, err
+	}
+	if findOptions.Projection == nil {
+		projection := bson.D{}
+		for _, column := range columns {
+			projection = append(projection, bson.E{Key: column, Value: 1})
+		}
+		findOptions.SetProjection(projection)
+	}
+
+	db := c.client.Database(database)
+	col := db.Collection(collection, options.Collection().SetReadPreference(readPref))
+	cur, err := withRetry(ctx, c, func() (*mongo.Cursor, error) { return col.Find(ctx, filter, c.withFindComment(findOptions)) })
+	if err != nil {
+		log.Printf("Error while finding documents: %v", err)
+		return nil, err
+	}
+	return readColumns(ctx, cur, columns)
+}
+
+// AggregateColumnar is Aggregate returning the given columns of the output
+// documents as a ColumnarResult.
+func (c *Client) AggregateColumnar(database string, collection string, pipeline any, columns []string) (*ColumnarResult, error) {
+	ctx, cancel := c.operationContext()
+	defer cancel()
+
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("columns cannot be empty")
+	}
+	db := c.client.Database(database)
+	col := db.Collection(collection)
+	cur, err := withRetry(ctx, c, func() (*mongo.Cursor, error) { return col.Aggregate(ctx, pipeline, c.aggregateOptions()) })
+	if err != nil {
+		log.Printf("Error while aggregating: %v", err)
+		return nil, err
+	}
+	return readColumns(ctx, cur, columns)
+}
+
+func readColumns(ctx context.Context, cur *mongo.Cursor, columns []string) (*ColumnarResult, error) {
+	defer cur.Close(ctx)
+
+	paths := make([][]string, len(columns))
+	for i, column := range columns {
+		paths[i] = strings.Split(column, ".")
+	}
+
+	result := &ColumnarResult{Columns: columns, Rows: [][]any{}}
+	for cur.Next(ctx) {
+		row := make([]any, len(columns))
+		for i, path := range paths {
+			value, err := cur.Current.LookupErr(path...)
+			if errors.Is(err, bsoncore.ErrElementNotFound) {
+				continue
+			}
+			if err != nil {
+				log.Printf(errDecodingDocuments, err)
+				return nil, err
+			}
+			if err := value.UnmarshalWithRegistry(mapRegistry, &row[i]); err != nil {
+				log.Printf(errDecodingDocuments, err)
+				return nil, err
+			}
+		}
+		result.Rows = append(result.Rows, row)
+	}
+	if err := cur.Err(); err != nil {
+		log.Printf("Error while iterating documents: %v", err)
+		return nil, err
+	}
+	return result, nil
+}
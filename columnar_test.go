@@ -0,0 +1,33 @@
+package xk6_mongo
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestMapRegistryDecodesDocumentsAsMaps(t *testing.T) {
+	raw, err := bson.Marshal(bson.D{{Key: "address", Value: bson.D{
+		{Key: "city", Value: "Berlin"},
+		{Key: "geo", Value: bson.A{bson.D{{Key: "lat", Value: 52.5}}}},
+	}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var value any
+	if err := bson.Raw(raw).Lookup("address").UnmarshalWithRegistry(mapRegistry, &value); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	address, ok := value.(bson.M)
+	if !ok || address["city"] != "Berlin" {
+		t.Fatalf("expected a bson.M, got %T %v", value, value)
+	}
+	geo, ok := address["geo"].(bson.A)
+	if !ok || len(geo) != 1 {
+		t.Fatalf("expected an array, got %T", address["geo"])
+	}
+	if _, ok := geo[0].(bson.M); !ok {
+		t.Fatalf("expected array elements to be bson.M, got %T", geo[0])
+	}
+}
@@ -0,0 +1,67 @@
+package xk6_mongo
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// writeCommands are the commands RunWriteCommand accepts.
+var writeCommands = []string{"insert", "update", "delete", "findAndModify"}
+
+// RunWriteCommand runs the write command (insert, update, delete or
+// findAndModify) against collection and returns the server's complete reply,
+// e.g. { n, nModified, upserted, writeErrors, writeConcernError, ok }. The
+// write methods only report counts, since the driver does not expose the
+// reply, and throw on partial failures. Here write errors and write concern
+// errors are part of the returned reply instead, and only a command that
+// fails as a whole throws. args holds the remaining command fields, e.g.
+// { documents: [...], ordered: false } for an insert.
+func (c *Client) RunWriteCommand(database string, command string, collection string, args any) (bson.M, error) {
+	if !slices.Contains(writeCommands, command) {
+		return nil, fmt.Errorf("unsupported write command %q, expected one of %v", command, writeCommands)
+	}
+	if c.dryRun("command", database, collection, "command", command, "args", args) {
+		return bson.M{"ok": 1}, nil
+	}
+
+	ctx, cancel := c.operationContext()
+	defer cancel()
+
+	raw, err := optionsMap(args)
+	if err != nil {
+		return nil, err
+	}
+	// The command name must be the first field of the command document.
+	cmd := bson.D{{Key: command, Value: collection}}
+	for key, value := range raw {
+		cmd = append(cmd, bson.E{Key: key, Value: value})
+	}
+
+	reply, err := writeCommandReply(c.client.Database(database).RunCommand(ctx, cmd).Raw())
+	if err != nil {
+		log.Printf("Error while running %s command: %v", command, err)
+		return nil, err
+	}
+	return reply, nil
+}
+
+// writeCommandReply decodes the reply of a write command. The driver reports
+// a reply with writeErrors or writeConcernError as a WriteException even when
+// the command succeeded, so the reply is returned as is in that case, and
+// only an error without a reply document is returned.
+func writeCommandReply(raw bson.Raw, err error) (bson.M, error) {
+	var writeErr mongo.WriteException
+	if err != nil && (!errors.As(err, &writeErr) || len(raw) == 0) {
+		return nil, err
+	}
+	var reply bson.M
+	if err := bson.Unmarshal(raw, &reply); err != nil {
+		return nil, fmt.Errorf("error decoding command reply: %w", err)
+	}
+	return reply, nil
+}
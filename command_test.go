@@ -0,0 +1,42 @@
+package xk6_mongo
+
+import (
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestWriteCommandReply(t *testing.T) {
+	raw, err := bson.Marshal(bson.D{
+		{Key: "n", Value: int32(1)},
+		{Key: "writeErrors", Value: bson.A{bson.D{
+			{Key: "index", Value: int32(1)},
+			{Key: "code", Value: int32(11000)},
+			{Key: "errmsg", Value: "E11000 duplicate key error"},
+		}}},
+		{Key: "ok", Value: 1.0},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	writeErr := mongo.WriteException{WriteErrors: mongo.WriteErrors{{Index: 1, Code: 11000, Message: "E11000 duplicate key error"}}}
+
+	reply, err := writeCommandReply(bson.Raw(raw), writeErr)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	writeErrors, ok := reply["writeErrors"].(bson.A)
+	if !ok || len(writeErrors) != 1 || reply["n"] != int32(1) {
+		t.Fatalf("unexpected reply %v", reply)
+	}
+
+	if _, err := writeCommandReply(nil, writeErr); err == nil {
+		t.Fatalf("expected error for a write exception without reply")
+	}
+	failed := errors.New("connection refused")
+	if _, err := writeCommandReply(bson.Raw(raw), failed); !errors.Is(err, failed) {
+		t.Fatalf("expected the command error, got %v", err)
+	}
+}
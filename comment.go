@@ -0,0 +1,111 @@
+package xk6_mongo
+
+import (
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SetComment attaches comment to the subsequent finds, counts, aggregations
+// and writes of the client. The server records it in its logs, the profiler and
+// $currentOp, which lets slow queries be traced back to the test step that
+// issued them, e.g. by setting the scenario name. A comment option passed to
+// a single call takes precedence, see withCallOptions, and an empty comment
+// removes it.
+func (c *Client) SetComment(comment string) {
+	c.comment = comment
+}
+
+// withCallOptions returns the client to run a single call of Aggregate or of
+// a write method with, given the optional trailing argument of the call:
+// either a comment or an object { comment, timeoutMS }. They replace the
+// client's comment and default timeout for this call only, so that steps of
+// one VU can be tagged and bounded differently without changing the client
+// shared by them.
+func (c *Client) withCallOptions(callOpts []any) (*Client, error) {
+	if len(callOpts) == 0 || callOpts[0] == nil {
+		return c, nil
+	}
+	if len(callOpts) > 1 {
+		return nil, fmt.Errorf("expected a single options argument, got %d", len(callOpts))
+	}
+	call := *c
+	if comment, ok := callOpts[0].(string); ok {
+		call.comment = comment
+		return &call, nil
+	}
+	raw, err := optionsMap(callOpts[0])
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range raw {
+		switch key {
+		case "comment":
+			comment, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("comment must be a string")
+			}
+			call.comment = comment
+		case "timeoutMS":
+			if call.timeout, err = parseCallTimeout(value); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("unsupported call option %q", key)
+		}
+	}
+	return &call, nil
+}
+
+// commentOption returns the client's comment for the driver options that take
+// any value, or nil, which the driver leaves out, when none is set.
+func (c *Client) commentOption() any {
+	if c.comment == "" {
+		return nil
+	}
+	return c.comment
+}
+
+// withFindComment sets the client's comment on opts unless it has one.
+func (c *Client) withFindComment(opts *options.FindOptions) *options.FindOptions {
+	if c.comment != "" && opts.Comment == nil {
+		opts.SetComment(c.comment)
+	}
+	return opts
+}
+
+// withAggregateComment sets the client's comment on opts unless it has one.
+func (c *Client) withAggregateComment(opts *options.AggregateOptions) *options.AggregateOptions {
+	if c.comment != "" && opts.Comment == nil {
+		opts.SetComment(c.comment)
+	}
+	return opts
+}
+
+func (c *Client) findOptions() *options.FindOptions {
+	return c.withFindComment(options.Find())
+}
+
+func (c *Client) countOptions() *options.CountOptions {
+	opts := options.Count()
+	if c.comment != "" {
+		opts.SetComment(c.comment)
+	}
+	return opts
+}
+
+func (c *Client) findOneOptions() *options.FindOneOptions {
+	opts := options.FindOne()
+	if c.comment != "" {
+		opts.SetComment(c.comment)
+	}
+	return opts
+}
+
+func (c *Client) aggregateOptions() *options.AggregateOptions {
+	opts := options.Aggregate()
+	if c.comment != "" {
+		opts.SetComment(c.comment)
+	}
+	return opts
+}
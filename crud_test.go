@@ -13,9 +13,9 @@ func TestCRUDOperations(t *testing.T) {
 		t.Skip("MONGODB_URI not set")
 	}
 
-	client := new(Mongo).NewClient(uri)
-	if client == nil {
-		t.Fatalf("failed to create client")
+	client, err := new(Mongo).NewClient(uri)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
 	}
 	defer client.Disconnect()
 
@@ -0,0 +1,175 @@
+package xk6_mongo
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Cursor is a handle over a server-side cursor that lets scripts consume a
+// large result set in batches instead of materializing it at once. Close
+// should be called once the script is done with it, otherwise it is closed
+// on Disconnect or when the VU stops.
+type Cursor struct {
+	cursor  *mongo.Cursor
+	ctx     context.Context
+	tracker *openCursors
+
+	// mu serializes the script's calls with the close on VU stop.
+	mu     sync.Mutex
+	closed bool
+}
+
+// newCursor wraps cur and tracks it with the client, see trackCursor.
+func (c *Client) newCursor(cur *mongo.Cursor) *Cursor {
+	cursor := &Cursor{cursor: cur, ctx: c.context(), tracker: c.cursors}
+	c.trackCursor(cursor)
+	return cursor
+}
+
+// Next returns up to n further documents. An empty result means the cursor
+// is exhausted.
+func (c *Cursor) Next(n int) ([]bson.M, error) {
+	results := make([]bson.M, 0, max(n, 0))
+	err := c.next(n, func() error {
+		var doc bson.M
+		if err := c.cursor.Decode(&doc); err != nil {
+			return err
+		}
+		results = append(results, doc)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// Close releases the server-side cursor. Closing it again does nothing.
+func (c *Cursor) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+	c.tracker.untrack(c)
+	if err := c.cursor.Close(c.ctx); err != nil {
+		log.Printf("Error while closing cursor: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (c *Cursor) next(n int, decode func() error) error {
+	if n <= 0 {
+		return fmt.Errorf("batch size must be positive, got %d", n)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return fmt.Errorf("cursor is closed")
+	}
+	for i := 0; i < n && c.cursor.Next(c.ctx); i++ {
+		if err := decode(); err != nil {
+			log.Printf(errDecodingDocuments, err)
+			return err
+		}
+	}
+	if err := c.cursor.Err(); err != nil {
+		log.Printf("Error while iterating cursor: %v", err)
+		return err
+	}
+	return nil
+}
+
+// FindCursor runs a find with the options of FindWithOptions, except
+// timeoutMS since the cursor outlives the call, and returns a Cursor to
+// consume the results in batches. Setting noCursorTimeout keeps
+// the server from closing the cursor after 10 minutes of inactivity, for slow
+// consumers; such cursors must be closed explicitly.
+func (c *Client) FindCursor(database string, collection string, filter any, opts any) (*Cursor, error) {
+	filter = c.coerceFilter(filter)
+	findOptions, readPref, err := prepareFindReadOptions(opts)
+	if err != nil {
+		log.Printf("Error while preparing find options: %v", err)
+		return nil, err
+	}
+	db := c.client.Database(database)
+	col := db.Collection(collection, options.Collection().SetReadPreference(readPref))
+	cur, err := col.Find(c.context(), filter, c.withFindComment(findOptions))
+	if err != nil {
+		log.Printf("Error while finding documents: %v", err)
+		return nil, err
+	}
+	return c.newCursor(cur), nil
+}
+
+// DistinctCursor returns the distinct values of a field in batches.
+type DistinctCursor struct {
+	cursor *Cursor
+}
+
+// Next returns up to n further distinct values, with documents decoded as
+// objects like those of Distinct. An empty result means all values have been
+// returned.
+func (d *DistinctCursor) Next(n int) ([]any, error) {
+	values := make([]any, 0, max(n, 0))
+	err := d.cursor.next(n, func() error {
+		var value any
+		if err := d.cursor.cursor.Current.Lookup("_id").UnmarshalWithRegistry(mapRegistry, &value); err != nil {
+			return err
+		}
+		values = append(values, value)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// Close releases the server-side cursor.
+func (d *DistinctCursor) Close() error {
+	return d.cursor.Close()
+}
+
+// FindDistinctPaged returns the distinct values of field among the documents
+// matching filter in ascending order through a cursor, using a $group
+// aggregation instead of the distinct command so that the result is not
+// bound by the 16MB document limit. Like distinct, an array field contributes
+// each of its elements and documents missing the field are skipped; unlike
+// distinct, so are documents where it is null.
+func (c *Client) FindDistinctPaged(database string, collection string, field string, filter any, batchSize int32) (*DistinctCursor, error) {
+	filter = c.coerceFilter(filter)
+	if field == "" {
+		return nil, fmt.Errorf("field cannot be empty")
+	}
+	if filter == nil {
+		filter = bson.D{}
+	}
+	pipeline := bson.A{
+		bson.D{{Key: "$match", Value: filter}},
+		bson.D{{Key: "$unwind", Value: "$" + field}},
+		bson.D{{Key: "$group", Value: bson.D{{Key: "_id", Value: "$" + field}}}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}},
+	}
+	opts := options.Aggregate().SetAllowDiskUse(true)
+	if batchSize > 0 {
+		opts.SetBatchSize(batchSize)
+	}
+
+	db := c.client.Database(database)
+	col := db.Collection(collection)
+	cur, err := col.Aggregate(c.context(), pipeline, c.withAggregateComment(opts))
+	if err != nil {
+		log.Printf("Error while getting distinct values: %v", err)
+		return nil, err
+	}
+	return &DistinctCursor{cursor: c.newCursor(cur)}, nil
+}
@@ -0,0 +1,73 @@
+package xk6_mongo
+
+import (
+	"context"
+	"sync"
+)
+
+// cursorCloser is a cursor handle given to scripts, such as Cursor and
+// TailCursor.
+type cursorCloser interface {
+	Close() error
+}
+
+// openCursors tracks the cursors of a client that scripts have not closed
+// yet, so that Disconnect and the end of the VU release them instead of
+// leaving them open on the server until they time out.
+type openCursors struct {
+	mu sync.Mutex
+	// cursors maps each open cursor to the function stopping its close on
+	// the VU context.
+	cursors map[cursorCloser]func() bool
+}
+
+// trackCursor registers cur with the client and closes it once the VU
+// context is done, i.e. when the VU is stopped or the test is aborted.
+func (c *Client) trackCursor(cur cursorCloser) {
+	if c.cursors == nil {
+		return
+	}
+	stop := func() bool { return false }
+	if c.vu != nil {
+		if vuCtx := c.vu.Context(); vuCtx != nil {
+			stop = context.AfterFunc(vuCtx, func() { _ = cur.Close() })
+		}
+	}
+	c.cursors.mu.Lock()
+	defer c.cursors.mu.Unlock()
+	if c.cursors.cursors == nil {
+		c.cursors.cursors = make(map[cursorCloser]func() bool)
+	}
+	c.cursors.cursors[cur] = stop
+}
+
+// untrack forgets cur once it is closed.
+func (o *openCursors) untrack(cur cursorCloser) {
+	if o == nil {
+		return
+	}
+	o.mu.Lock()
+	stop, ok := o.cursors[cur]
+	delete(o.cursors, cur)
+	o.mu.Unlock()
+	if ok {
+		stop()
+	}
+}
+
+// closeCursors closes every cursor of the client that is still open.
+func (c *Client) closeCursors() {
+	if c.cursors == nil {
+		return
+	}
+	c.cursors.mu.Lock()
+	cursors := c.cursors.cursors
+	c.cursors.cursors = nil
+	c.cursors.mu.Unlock()
+
+	// Close untracks the cursor, so the lock must not be held here.
+	for cur, stop := range cursors {
+		stop()
+		_ = cur.Close()
+	}
+}
@@ -0,0 +1,33 @@
+package xk6_mongo
+
+import "testing"
+
+type fakeCursor struct {
+	tracker *openCursors
+	closes  int
+}
+
+func (f *fakeCursor) Close() error {
+	f.closes++
+	f.tracker.untrack(f)
+	return nil
+}
+
+func TestCloseCursors(t *testing.T) {
+	c := &Client{cursors: &openCursors{}}
+	closed := &fakeCursor{tracker: c.cursors}
+	open := &fakeCursor{tracker: c.cursors}
+	c.trackCursor(closed)
+	c.trackCursor(open)
+
+	if err := closed.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	c.closeCursors()
+	if closed.closes != 1 || open.closes != 1 {
+		t.Fatalf("unexpected closes %d and %d", closed.closes, open.closes)
+	}
+	if len(c.cursors.cursors) != 0 {
+		t.Fatalf("cursors still tracked: %v", c.cursors.cursors)
+	}
+}
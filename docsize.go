@@ -0,0 +1,56 @@
+package xk6_mongo
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// maxDocSizeFields is the number of largest fields named in the error of an
+// oversized document.
+const maxDocSizeFields = 3
+
+// SetMaxDocBytes makes Insert and InsertMany reject documents whose BSON
+// encoding is larger than maxBytes before sending them, with an error naming
+// the largest fields, instead of failing on the server's 16MB limit midway
+// through a batch. Zero or a negative value disables the check.
+func (c *Client) SetMaxDocBytes(maxBytes int64) {
+	c.maxDocBytes = maxBytes
+}
+
+// checkDocSize returns an error when doc encodes to more than the client's
+// maxDocBytes.
+func (c *Client) checkDocSize(doc any) error {
+	if c.maxDocBytes <= 0 {
+		return nil
+	}
+	raw, err := bson.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("cannot encode document: %w", err)
+	}
+	if int64(len(raw)) <= c.maxDocBytes {
+		return nil
+	}
+	return fmt.Errorf("document of %d bytes exceeds maxDocBytes of %d, largest fields: %s",
+		len(raw), c.maxDocBytes, largestFields(bson.Raw(raw), maxDocSizeFields))
+}
+
+// largestFields formats the n largest top-level fields of doc with their
+// encoded sizes, largest first.
+func largestFields(doc bson.Raw, n int) string {
+	elems, err := doc.Elements()
+	if err != nil {
+		return "unknown"
+	}
+	sort.SliceStable(elems, func(i, j int) bool { return len(elems[i]) > len(elems[j]) })
+	if len(elems) > n {
+		elems = elems[:n]
+	}
+	fields := make([]string, len(elems))
+	for i, elem := range elems {
+		fields[i] = fmt.Sprintf("%s (%d bytes)", elem.Key(), len(elem))
+	}
+	return strings.Join(fields, ", ")
+}
@@ -0,0 +1,40 @@
+package xk6_mongo
+
+import (
+	"strings"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestCheckDocSize(t *testing.T) {
+	doc := bson.D{
+		{Key: "name", Value: "small"},
+		{Key: "payload", Value: strings.Repeat("x", 1000)},
+		{Key: "tags", Value: bson.A{"a", "b"}},
+		{Key: "n", Value: 1},
+	}
+
+	c := &Client{}
+	if err := c.checkDocSize(doc); err != nil {
+		t.Errorf("unexpected error without a limit: %v", err)
+	}
+
+	c.SetMaxDocBytes(10000)
+	if err := c.checkDocSize(doc); err != nil {
+		t.Errorf("unexpected error below the limit: %v", err)
+	}
+
+	c.SetMaxDocBytes(500)
+	err := c.checkDocSize(doc)
+	if err == nil {
+		t.Fatal("expected an error above the limit")
+	}
+	msg := err.Error()
+	if !strings.Contains(msg, "largest fields: payload (") {
+		t.Errorf("expected payload to be named first, got %q", msg)
+	}
+	if strings.Contains(msg, " n (") {
+		t.Errorf("expected only the %d largest fields, got %q", maxDocSizeFields, msg)
+	}
+}
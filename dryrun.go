@@ -0,0 +1,51 @@
+package xk6_mongo
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// SetDryRun turns the dry-run mode of the client on or off. In dry-run mode
+// the write methods log the operation they would run and return without
+// contacting the server, reporting zero affected documents. They are Insert,
+// InsertMany, InsertManyDetailed, InsertRaw, InsertRows, InsertManyBatched,
+// InsertTimeSeries, SeedDocuments, the batches of StartIngest, Upsert,
+// UpsertManyWithFilters, BulkUpsert, UpdateOne, UpdateMany,
+// UpdateOnePipeline, UpdateManyPipeline, UpdateManyInBatches, PushBounded,
+// FindOneAndUpdate, DeleteOne, DeleteMany, DeleteManyInBatches,
+// DeleteLimited, Merge, CopyDocuments, AggregateOut, CreateCollection,
+// DropCollection, CreateIndex, CreateIndexes, StartCreateIndex, GridFSUpload,
+// GridFSDelete, RestoreBSON, Snapshot, Restore, RunWriteCommand, KillOp,
+// KillLongRunning, SetFCV and SetBalancerState, as well as their async
+// variants. Reads are still executed, including the one KillLongRunning lists
+// the operations to kill with.
+func (c *Client) SetDryRun(enabled bool) {
+	c.dryRunEnabled = enabled
+}
+
+// dryRun logs op with its details, given as alternating names and values, and
+// reports true when the client is in dry-run mode and op must not be run.
+func (c *Client) dryRun(op string, database string, collection string, details ...any) bool {
+	if !c.dryRunEnabled {
+		return false
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "dry run: %s on %s.%s", op, database, collection)
+	for i := 0; i+1 < len(details); i += 2 {
+		fmt.Fprintf(&b, " %v=%s", details[i], formatDryRunValue(details[i+1]))
+	}
+	log.Print(b.String())
+	return true
+}
+
+// formatDryRunValue renders documents as relaxed Extended JSON, which reads
+// like the shell syntax, and any other value with its default format.
+func formatDryRunValue(value any) string {
+	if out, err := bson.MarshalExtJSON(value, false, false); err == nil {
+		return string(out)
+	}
+	return fmt.Sprintf("%v", value)
+}
@@ -0,0 +1,187 @@
+package xk6_mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// errEncryptionNotBuilt is returned by the encryption methods when the
+// extension was built without the cse build tag, which links libmongocrypt.
+var errEncryptionNotBuilt = errors.New("client-side encryption requires building the extension with -tags cse and libmongocrypt installed")
+
+// encryption holds the client-side encryption state of a client: the KMS
+// providers and default key vault it was created with and one driver
+// ClientEncryption per key vault namespace, opened on first use.
+type encryption struct {
+	mu                sync.Mutex
+	kmsProviders      map[string]map[string]any
+	keyVaultNamespace string
+	vaults            map[string]*mongo.ClientEncryption
+}
+
+// encryptionAlgorithms maps short algorithm names to the driver's.
+var encryptionAlgorithms = map[string]string{
+	"deterministic": "AEAD_AES_256_CBC_HMAC_SHA_512-Deterministic",
+	"random":        "AEAD_AES_256_CBC_HMAC_SHA_512-Random",
+}
+
+// clientEncryption returns the ClientEncryption for keyVaultNamespace
+// ("database.collection"), or for the client's keyVaultNamespace setting when
+// empty, opening it on first use with the client itself as the key vault
+// client.
+func (c *Client) clientEncryption(keyVaultNamespace string) (*mongo.ClientEncryption, error) {
+	if !cseEnabled {
+		return nil, errEncryptionNotBuilt
+	}
+	if c.encryption == nil {
+		return nil, fmt.Errorf("client was created without kmsProviders")
+	}
+	if keyVaultNamespace == "" {
+		keyVaultNamespace = c.encryption.keyVaultNamespace
+	}
+	if keyVaultNamespace == "" {
+		return nil, fmt.Errorf("key vault namespace must be set")
+	}
+
+	c.encryption.mu.Lock()
+	defer c.encryption.mu.Unlock()
+	if ce, ok := c.encryption.vaults[keyVaultNamespace]; ok {
+		return ce, nil
+	}
+	opts := options.ClientEncryption().
+		SetKeyVaultNamespace(keyVaultNamespace).
+		SetKmsProviders(c.encryption.kmsProviders)
+	ce, err := mongo.NewClientEncryption(c.client, opts)
+	if err != nil {
+		return nil, err
+	}
+	c.encryption.vaults[keyVaultNamespace] = ce
+	return ce, nil
+}
+
+// closeEncryption closes every ClientEncryption opened by the client, which
+// must happen before the key vault client is disconnected.
+func (c *Client) closeEncryption() {
+	if c.encryption == nil {
+		return
+	}
+	c.encryption.mu.Lock()
+	defer c.encryption.mu.Unlock()
+	for namespace, ce := range c.encryption.vaults {
+		if err := ce.Close(context.Background()); err != nil {
+			log.Printf("Error while closing client encryption for %s: %v", namespace, err)
+		}
+		delete(c.encryption.vaults, namespace)
+	}
+}
+
+// CreateDataKey creates a data encryption key in the key vault collection
+// keyVaultNamespace ("database.collection", the client's keyVaultNamespace
+// setting when empty), protected by the master key of
+// kmsProvider, and returns the new key's id as a UUID string. The provider's
+// credentials come from the kmsProviders the client was created with. opts may
+// set masterKey, the provider-specific master key document (not needed for
+// the local provider), and keyAltNames, alternate names for the key.
+func (c *Client) CreateDataKey(kmsProvider string, keyVaultNamespace string, opts any) (string, error) {
+	ctx, cancel := c.operationContext()
+	defer cancel()
+
+	raw, err := optionsMap(opts)
+	if err != nil {
+		return "", err
+	}
+	keyOptions := options.DataKey()
+	for key, value := range raw {
+		switch key {
+		case "masterKey":
+			keyOptions.SetMasterKey(value)
+		case "keyAltNames":
+			names, err := toStrings(value)
+			if err != nil {
+				return "", fmt.Errorf("invalid keyAltNames: %w", err)
+			}
+			keyOptions.SetKeyAltNames(names)
+		default:
+			return "", fmt.Errorf("unsupported data key option %q", key)
+		}
+	}
+
+	ce, err := c.clientEncryption(keyVaultNamespace)
+	if err != nil {
+		log.Printf("Error while opening client encryption: %v", err)
+		return "", err
+	}
+	id, err := ce.CreateDataKey(ctx, kmsProvider, keyOptions)
+	if err != nil {
+		log.Printf("Error while creating data key: %v", err)
+		return "", err
+	}
+	return (&Mongo{}).ConvertUuidToString(id)
+}
+
+// Encrypt explicitly encrypts value with the data key whose id is the UUID
+// string keyId, taken from the client's default key vault. algorithm is the
+// full driver name, e.g. "AEAD_AES_256_CBC_HMAC_SHA_512-Deterministic", or
+// "deterministic" or "random" for short. The ciphertext is returned as BSON
+// binary data that can be stored as is or passed to Decrypt.
+func (c *Client) Encrypt(value any, keyId string, algorithm string) (primitive.Binary, error) {
+	ctx, cancel := c.operationContext()
+	defer cancel()
+
+	id, err := (&Mongo{}).ConvertStringToUuid(keyId)
+	if err != nil {
+		return primitive.Binary{}, err
+	}
+	if name, ok := encryptionAlgorithms[algorithm]; ok {
+		algorithm = name
+	}
+	valueType, data, err := bson.MarshalValue(value)
+	if err != nil {
+		return primitive.Binary{}, fmt.Errorf("error encoding value: %w", err)
+	}
+
+	ce, err := c.clientEncryption("")
+	if err != nil {
+		log.Printf("Error while opening client encryption: %v", err)
+		return primitive.Binary{}, err
+	}
+	opts := options.Encrypt().SetKeyID(id).SetAlgorithm(algorithm)
+	ciphertext, err := ce.Encrypt(ctx, bson.RawValue{Type: valueType, Value: data}, opts)
+	if err != nil {
+		log.Printf("Error while encrypting value: %v", err)
+		return primitive.Binary{}, err
+	}
+	return ciphertext, nil
+}
+
+// Decrypt decrypts a ciphertext produced by Encrypt, or read from an
+// encrypted field, and returns the original value.
+func (c *Client) Decrypt(ciphertext primitive.Binary) (any, error) {
+	ctx, cancel := c.operationContext()
+	defer cancel()
+
+	ce, err := c.clientEncryption("")
+	if err != nil {
+		log.Printf("Error while opening client encryption: %v", err)
+		return nil, err
+	}
+	raw, err := ce.Decrypt(ctx, ciphertext)
+	if err != nil {
+		log.Printf("Error while decrypting value: %v", err)
+		return nil, err
+	}
+	var value any
+	if err := raw.Unmarshal(&value); err != nil {
+		log.Printf("Error while decoding decrypted value: %v", err)
+		return nil, err
+	}
+	return value, nil
+}
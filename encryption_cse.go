@@ -0,0 +1,6 @@
+//go:build cse
+
+package xk6_mongo
+
+// cseEnabled reports whether the extension was built with libmongocrypt.
+const cseEnabled = true
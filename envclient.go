@@ -0,0 +1,104 @@
+package xk6_mongo
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// NewClientFromEnv creates a client from a connection URI assembled from the
+// environment variables <prefix>_HOST, <prefix>_PORT, <prefix>_USER,
+// <prefix>_PASSWORD, <prefix>_DB and <prefix>_OPTIONS, of which only HOST is
+// required. The prefix must not be empty. HOST may list several comma
+// separated hosts, and PORT applies to those without a port of their own.
+// Setting <prefix>_SRV to true uses a mongodb+srv URI, which takes a single
+// host and no port. OPTIONS is a query string such as replicaSet=rs0&tls=true.
+// Credentials are used as set, without trimming whitespace, URL-encoded, and
+// the URI is never logged. Variables given to k6 with -e take precedence over
+// the process environment. The optional config is the one of NewClient.
+func (m *Mongo) NewClientFromEnv(prefix string, config ...any) (*Client, error) {
+	connURI, err := connURIFromEnv(prefix, m.lookupEnv)
+	if err != nil {
+		log.Printf("Error while building the connection URI: %v", err)
+		return nil, err
+	}
+	return m.NewClient(connURI, config...)
+}
+
+func (m *Mongo) lookupEnv(key string) (string, bool) {
+	if value, ok := m.env[key]; ok {
+		return value, true
+	}
+	return os.LookupEnv(key)
+}
+
+func connURIFromEnv(prefix string, lookup func(string) (string, bool)) (string, error) {
+	// Without a prefix the plain USER and HOST set by shells and CI runners
+	// would end up in the URI.
+	if prefix == "" {
+		return "", fmt.Errorf("environment variable prefix must not be empty")
+	}
+	envName := func(name string) string {
+		return prefix + "_" + name
+	}
+	raw := func(name string) string {
+		value, _ := lookup(envName(name))
+		return value
+	}
+	env := func(name string) string {
+		return strings.TrimSpace(raw(name))
+	}
+
+	hostList := env("HOST")
+	if hostList == "" {
+		return "", fmt.Errorf("environment variable %s must be set", envName("HOST"))
+	}
+	srv := strings.EqualFold(env("SRV"), "true")
+	port := env("PORT")
+
+	scheme := "mongodb"
+	var hosts []string
+	for _, host := range strings.Split(hostList, ",") {
+		host = strings.TrimSpace(host)
+		if host == "" {
+			continue
+		}
+		if port != "" && !srv && !hasPort(host) {
+			host += ":" + port
+		}
+		hosts = append(hosts, host)
+	}
+	if srv {
+		scheme = "mongodb+srv"
+		if len(hosts) != 1 || port != "" {
+			return "", fmt.Errorf("%s requires a single host and no port", envName("SRV"))
+		}
+	}
+
+	u := url.URL{Scheme: scheme, Host: strings.Join(hosts, ",")}
+	// Credentials are taken verbatim, as whitespace may be part of them.
+	if user := raw("USER"); user != "" {
+		if password := raw("PASSWORD"); password != "" {
+			u.User = url.UserPassword(user, password)
+		} else {
+			u.User = url.User(user)
+		}
+	}
+	u.Path = "/" + env("DB")
+	if opts := strings.TrimPrefix(env("OPTIONS"), "?"); opts != "" {
+		if _, err := url.ParseQuery(opts); err != nil {
+			return "", fmt.Errorf("invalid %s: %w", envName("OPTIONS"), err)
+		}
+		u.RawQuery = opts
+	}
+	return u.String(), nil
+}
+
+// hasPort reports whether host ends with a port, allowing for bracketed IPv6
+// addresses.
+func hasPort(host string) bool {
+	i := strings.LastIndex(host, ":")
+	return i >= 0 && i > strings.LastIndex(host, "]")
+}
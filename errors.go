@@ -0,0 +1,54 @@
+package xk6_mongo
+
+import (
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// WriteConcernError is returned by the write methods when the server applied
+// the write but could not satisfy the requested write concern, for example
+// because a w:"majority" write timed out waiting for replication. Scripts can
+// tell it apart from other failures through the `value` of the thrown
+// exception, whose category is always "writeConcern".
+type WriteConcernError struct {
+	Category string `js:"category"`
+	Code     int    `js:"code"`
+	Name     string `js:"name"`
+	Message  string `js:"message"`
+}
+
+const writeConcernCategory = "writeConcern"
+
+func (e *WriteConcernError) Error() string {
+	return fmt.Sprintf("write concern error (%d %s): %s", e.Code, e.Name, e.Message)
+}
+
+// classifyWriteError turns write errors that only failed the write concern
+// into a *WriteConcernError and returns every other error unchanged.
+func classifyWriteError(err error) error {
+	var writeException mongo.WriteException
+	if errors.As(err, &writeException) {
+		if len(writeException.WriteErrors) == 0 && writeException.WriteConcernError != nil {
+			return newWriteConcernError(writeException.WriteConcernError)
+		}
+		return err
+	}
+	var bulkException mongo.BulkWriteException
+	if errors.As(err, &bulkException) {
+		if len(bulkException.WriteErrors) == 0 && bulkException.WriteConcernError != nil {
+			return newWriteConcernError(bulkException.WriteConcernError)
+		}
+	}
+	return err
+}
+
+func newWriteConcernError(wce *mongo.WriteConcernError) *WriteConcernError {
+	return &WriteConcernError{
+		Category: writeConcernCategory,
+		Code:     wce.Code,
+		Name:     wce.Name,
+		Message:  wce.Message,
+	}
+}
@@ -0,0 +1,49 @@
+package xk6_mongo
+
+import (
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestClassifyWriteError(t *testing.T) {
+	concernOnly := mongo.WriteException{
+		WriteConcernError: &mongo.WriteConcernError{Code: 64, Name: "WriteConcernFailed", Message: "waiting for replication timed out"},
+	}
+	var wce *WriteConcernError
+	if !errors.As(classifyWriteError(concernOnly), &wce) {
+		t.Fatalf("expected a write concern error")
+	}
+	if wce.Category != writeConcernCategory || wce.Code != 64 {
+		t.Fatalf("unexpected write concern error %+v", wce)
+	}
+
+	withWriteErrors := mongo.WriteException{
+		WriteErrors:       mongo.WriteErrors{{Code: 11000, Message: "duplicate key"}},
+		WriteConcernError: concernOnly.WriteConcernError,
+	}
+	if errors.As(classifyWriteError(withWriteErrors), &wce) {
+		t.Fatalf("write errors must not be reported as write concern errors")
+	}
+}
+
+func TestUnorderedInsertedCount(t *testing.T) {
+	res := &mongo.InsertManyResult{InsertedIDs: []any{1, 2, 3, 4}}
+	if n := unorderedInsertedCount(res, nil); n != 4 {
+		t.Fatalf("expected 4 inserted, got %d", n)
+	}
+
+	partial := mongo.BulkWriteException{
+		WriteErrors: []mongo.BulkWriteError{
+			{WriteError: mongo.WriteError{Index: 1, Code: 11000, Message: "duplicate key"}},
+			{WriteError: mongo.WriteError{Index: 3, Code: 11000, Message: "duplicate key"}},
+		},
+	}
+	if n := unorderedInsertedCount(res, partial); n != 2 {
+		t.Fatalf("expected 2 inserted, got %d", n)
+	}
+	if n := unorderedInsertedCount(nil, partial); n != 0 {
+		t.Fatalf("expected 0 inserted without a result, got %d", n)
+	}
+}
@@ -0,0 +1,14 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export default async () => {
+  const pipeline = [
+    { $match: { correlationId: "test--mongodb" } },
+    { $group: { _id: "$locale", count: { $sum: 1 } } }
+  ];
+
+  // The event loop keeps running timers and other promises while the aggregation is in flight.
+  const result = await client.aggregateAsync("testdb", "testcollection", pipeline);
+  console.log(`Aggregation result: ${JSON.stringify(result)}`);
+}
@@ -0,0 +1,18 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export default () => {
+  const pipeline = [
+    {$match: {status: "paid"}},
+    {$sort: {createdAt: -1}},
+    {$limit: 20},
+  ];
+
+  // Force the compound index instead of a blocking sort.
+  const docs = client.aggregateWithOptions("testdb", "orders", pipeline, {
+    hint: [["status", 1], ["createdAt", -1]],
+    maxTimeMS: 2000,
+  });
+  console.log(`Aggregated ${docs.length} documents`);
+}
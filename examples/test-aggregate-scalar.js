@@ -0,0 +1,15 @@
+import xk6_mongo from 'k6/x/mongo';
+import { check } from 'k6';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export default () => {
+  const avgPrice = client.aggregateScalar("testdb", "orders", [
+    {$match: {status: "paid"}},
+    {$group: {_id: null, avgPrice: {$avg: "$price"}}},
+  ], "avgPrice");
+
+  check(avgPrice, {
+    'average price is below 100': (v) => v !== null && v < 100,
+  });
+}
@@ -0,0 +1,13 @@
+import xk6_mongo from 'k6/x/mongo';
+import { check } from 'k6';
+
+// Connect through mongos, then take one shard offline during the test.
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export default () => {
+  const docs = client.findWithOptions("testdb", "orders", {status: "open"}, {
+    allowPartialResults: true,
+    limit: 100
+  });
+  check(docs, {'still serves data': (d) => d.length > 0});
+}
@@ -0,0 +1,15 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export default async () => {
+  // A user opening a dashboard fires several queries in parallel.
+  const [profile, recent, total] = await Promise.all([
+    client.findOneAsync("testdb", "testcollection", {correlationId: `test--mongodb`}),
+    client.findAsync("testdb", "testcollection", {locale: "en"}, {time: -1}, 10),
+    client.countDocumentsAsync("testdb", "testcollection", {}),
+  ]);
+  console.log(`profile=${profile._id} recent=${recent.length} total=${total}`);
+
+  await client.insertAsync("testdb", "testcollection", {correlationId: `test--mongodb`, time: new Date()});
+}
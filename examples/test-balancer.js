@@ -0,0 +1,18 @@
+import xk6_mongo from 'k6/x/mongo';
+
+// Connect through mongos.
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export function setup() {
+  client.setBalancerState(false);
+  const state = client.getBalancerState();
+  console.log(`Balancer mode before the test: ${state.mode}`);
+}
+
+export default () => {
+  client.insert("testdb", "sharded", {correlationId: `test--mongodb`, n: Math.random()});
+}
+
+export function teardown() {
+  client.setBalancerState(true);
+}
@@ -0,0 +1,11 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export default () => {
+  const result = client.bulkUpsert("testdb", "testcollection", [
+    {query: {correlationId: "alpha"}, update: {$inc: {hits: 1}}},
+    {query: {correlationId: "beta"}, update: {status: "seen"}},
+  ]);
+  console.log(`modified=${result.modifiedCount} upserted=${result.upsertedCount}`);
+}
@@ -0,0 +1,12 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+client.setComment("k6-checkout-scenario");
+
+export default () => {
+  // Per-call comments tag each step without changing the client's comment.
+  client.insert("testdb", "carts", {user: __VU, items: []}, {comment: "add-cart"});
+  client.updateOne("testdb", "carts", {user: __VU}, {$push: {items: "sku-1"}}, {comment: "add-item", timeoutMS: 500});
+  client.aggregate("testdb", "carts", [{$match: {user: __VU}}, {$count: "n"}], "count-carts");
+  client.deleteMany("testdb", "carts", {user: __VU});
+}
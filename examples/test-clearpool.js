@@ -0,0 +1,12 @@
+import xk6_mongo from 'k6/x/mongo';
+import { Trend } from 'k6/metrics';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+const coldStart = new Trend('cold_start_find', true);
+
+export default () => {
+  client.clearPool();
+  const start = Date.now();
+  client.findOne("testdb", "testcollection", {correlationId: `test--mongodb`});
+  coldStart.add(Date.now() - start);
+}
@@ -0,0 +1,11 @@
+import xk6_mongo from 'k6/x/mongo';
+
+// Run with e.g.
+//   k6 run -e MONGO_HOST=localhost -e MONGO_PORT=27017 -e MONGO_USER=k6 \
+//     -e MONGO_PASSWORD='p@ss:w/rd' -e MONGO_OPTIONS='authSource=admin' test-client-from-env.js
+const client = xk6_mongo.newClientFromEnv("MONGO", { appName: "k6-env-test" });
+
+export default () => {
+  const doc = client.findOne("testdb", "testcollection", {correlationId: `test--mongodb`});
+  console.log(`Found document: ${JSON.stringify(doc)}`);
+}
@@ -0,0 +1,11 @@
+import xk6_mongo from 'k6/x/mongo';
+
+// Cluster time is only reported by replica set members and mongos.
+const client = xk6_mongo.newClient('mongodb://localhost:27017/?replicaSet=rs0');
+
+export default () => {
+  const before = client.clusterTime();
+  client.insert("testdb", "testcollection", {correlationId: `test--mongodb`});
+  const after = client.clusterTime();
+  console.log(`Insert applied between ${before.t}.${before.i} and ${after.t}.${after.i}`);
+}
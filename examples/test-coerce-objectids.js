@@ -0,0 +1,15 @@
+import xk6_mongo from 'k6/x/mongo';
+import http from 'k6/http';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+client.setCoerceObjectIds(true);
+
+export default () => {
+  // The API returns ids as hex strings, e.g. "64b7f3a2c9e77a1d2f3b4c5d".
+  const order = http.post('http://localhost:8080/orders', JSON.stringify({sku: "A-1"})).json();
+
+  const doc = client.findOne("testdb", "orders", {_id: order.id});
+  console.log(`Stored order: ${JSON.stringify(doc)}`);
+
+  client.deleteOne("testdb", "orders", {_id: {$eq: order.id}});
+}
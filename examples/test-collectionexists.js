@@ -0,0 +1,13 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export function setup() {
+  if (client.collectionExists("testdb", "testcollection")) {
+    client.dropCollection("testdb", "testcollection");
+  }
+}
+
+export default () => {
+  console.log(`testcollection exists: ${client.collectionExists("testdb", "testcollection")}`);
+}
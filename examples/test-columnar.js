@@ -0,0 +1,18 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export default () => {
+  const result = client.findColumnar("testdb", "testcollection", {locale: "en"}, ["correlationId", "title", "time"], {limit: 10000});
+  const titleIdx = result.columns.indexOf("title");
+  let longTitles = 0;
+  for (const row of result.rows) {
+    if (row[titleIdx] && row[titleIdx].length > 10) longTitles++;
+  }
+  console.log(`${result.rows.length} rows, ${longTitles} with long titles`);
+
+  const perLocale = client.aggregateColumnar("testdb", "testcollection", [
+    { $group: { _id: "$locale", count: { $sum: 1 } } }
+  ], ["_id", "count"]);
+  console.log(JSON.stringify(perLocale.rows));
+}
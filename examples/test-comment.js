@@ -0,0 +1,14 @@
+import xk6_mongo from 'k6/x/mongo';
+import exec from 'k6/execution';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export default () => {
+  // Lets DBAs trace slow queries in the server logs back to the scenario.
+  client.setComment(`k6:${exec.scenario.name}`);
+  client.find("testdb", "testcollection", {correlationId: `test--mongodb`}, null, 10);
+  client.updateOne("testdb", "testcollection", {correlationId: `test--mongodb`}, {$inc: {hits: 1}});
+
+  // A per-call comment takes precedence.
+  client.findWithOptions("testdb", "testcollection", {}, {limit: 5, comment: "k6:dashboard-widget"});
+}
@@ -0,0 +1,11 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export function setup() {
+  client.copyDocuments("testdb", "testcollection", "scratchdb", "testcollection_copy", {locale: "en"});
+}
+
+export default () => {
+  client.find("scratchdb", "testcollection_copy", {locale: "en"}, null, 10);
+}
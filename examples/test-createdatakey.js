@@ -0,0 +1,17 @@
+import xk6_mongo from 'k6/x/mongo';
+
+// Requires a k6 binary built with -tags=cse, see the README. The local master
+// key must be 96 bytes, base64 encoded, e.g. `openssl rand -base64 96`.
+const client = xk6_mongo.newClient('mongodb://localhost:27017', {
+  kmsProviders: {local: {key: __ENV.LOCAL_MASTER_KEY}},
+});
+
+export function setup() {
+  const keyId = client.createDataKey("local", "encryption.__keyVault", {keyAltNames: [`k6-${Date.now()}`]});
+  console.log(`Created data key ${keyId}`);
+  return {keyId};
+}
+
+export default (data) => {
+  console.log(`Using data key ${data.keyId}`);
+}
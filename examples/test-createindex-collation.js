@@ -0,0 +1,24 @@
+import xk6_mongo from 'k6/x/mongo';
+import { check } from 'k6';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export function setup() {
+  client.createIndex("testdb", "users", {email: 1}, {
+    name: "email_ci_unique",
+    unique: true,
+    collation: {locale: "en", strength: 2},
+  });
+}
+
+export default () => {
+  const email = `user-${__VU}-${__ITER}@example.com`;
+  client.insert("testdb", "users", {email: email});
+  let rejected = false;
+  try {
+    client.insert("testdb", "users", {email: email.toUpperCase()});
+  } catch (e) {
+    rejected = true;
+  }
+  check(rejected, {'case variant is rejected': (r) => r});
+}
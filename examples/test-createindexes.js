@@ -0,0 +1,17 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export function setup() {
+  const names = client.createIndexes("testdb", "testcollection", [
+    { keys: { correlationId: 1 } },
+    { keys: [["locale", 1], ["time", -1]], options: { name: "locale_time" } },
+    { keys: { url: 1 }, options: { unique: true, sparse: true } }
+  ]);
+  console.log(`Created indexes: ${names}`);
+}
+
+export default () => {
+  let result = client.findOne("testdb", "testcollection", {correlationId: `test--mongodb`});
+  console.log(JSON.stringify(result));
+}
@@ -0,0 +1,10 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export default () => {
+  const ops = client.currentOp({ active: true, secs_running: { $gte: 1 } });
+  for (const op of ops) {
+    console.log(`${op.opid} ${op.op} on ${op.ns} running for ${op.secs_running}s`);
+  }
+}
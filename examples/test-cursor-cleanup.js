@@ -0,0 +1,25 @@
+import xk6_mongo from 'k6/x/mongo';
+import exec from 'k6/execution';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export const options = {
+  vus: 20,
+  duration: '30s',
+};
+
+export default () => {
+  const cursor = client.findCursor("testdb", "testcollection", {}, {batchSize: 100});
+  const batch = cursor.next(100);
+  if (batch.length === 0) {
+    // Aborting without cursor.close(): the extension closes the cursor
+    // when the VU stops.
+    exec.test.abort("no documents");
+  }
+  cursor.close();
+}
+
+export function teardown() {
+  // Closes any cursor still open on this client.
+  client.disconnect();
+}
@@ -0,0 +1,9 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export default () => {
+  const filter = xk6_mongo.dateRangeFilter("time", "2024-01-01", "2024-02-01T00:00:00Z");
+  const docs = client.find("testdb", "testcollection", filter, null, 100);
+  console.log(`Found ${docs.length} documents from January 2024`);
+}
@@ -0,0 +1,11 @@
+import xk6_mongo from 'k6/x/mongo';
+import { sleep } from 'k6';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export default () => {
+  // Trickle the cleanup: at most 500 expired documents per second.
+  const deleted = client.deleteLimited("testdb", "sessions", {expiresAt: {$lt: new Date()}}, 500);
+  console.log(`Deleted ${deleted} expired sessions`);
+  sleep(1);
+}
@@ -0,0 +1,10 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export default () => {
+  let deleted = client.deleteManyInBatches("testdb", "testcollection", {locale: "en"}, 1000, (soFar) => {
+    console.log(`Deleted ${soFar} documents so far`);
+  });
+  console.log(`Deleted ${deleted} documents in total`);
+}
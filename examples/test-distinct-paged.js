@@ -0,0 +1,16 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export default () => {
+  const cursor = client.findDistinctPaged("testdb", "testcollection", "correlationId", {}, 1000);
+  let total = 0;
+  try {
+    for (let values = cursor.next(1000); values.length > 0; values = cursor.next(1000)) {
+      total += values.length;
+    }
+  } finally {
+    cursor.close();
+  }
+  console.log(`Distinct correlationId values: ${total}`);
+}
@@ -0,0 +1,13 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export default () => {
+  // A composite _id only matches when its fields are in the same order.
+  const id = xk6_mongo.doc([["tenant", "acme"], ["orderNo", __ITER]]);
+  const order = xk6_mongo.doc([["_id", id], ["status", "new"], ["createdAt", new Date()]]);
+
+  client.insert("testdb", "orders", order);
+  const stored = client.findOne("testdb", "orders", xk6_mongo.doc([["_id", id]]));
+  console.log(`Stored order ${JSON.stringify(stored)}`);
+}
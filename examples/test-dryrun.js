@@ -0,0 +1,12 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+// Log the writes instead of running them while the script is being written,
+// e.g. k6 run -e DRY_RUN=1 test-dryrun.js
+client.setDryRun(__ENV.DRY_RUN === "1");
+
+export default () => {
+  const account = `acct-${__VU}`;
+  client.updateOne("testdb", "accounts", {_id: account}, {$inc: {balance: -10}, $set: {updatedAt: new Date()}});
+  client.deleteMany("testdb", "sessions", {account: account, expired: true});
+}
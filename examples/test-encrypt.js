@@ -0,0 +1,22 @@
+import xk6_mongo from 'k6/x/mongo';
+import { Trend } from 'k6/metrics';
+
+// Requires a k6 binary built with -tags=cse, see the README.
+const client = xk6_mongo.newClient('mongodb://localhost:27017', {
+  kmsProviders: {local: {key: __ENV.LOCAL_MASTER_KEY}},
+  keyVaultNamespace: "encryption.__keyVault",
+});
+const encryptDuration = new Trend('field_encrypt_duration', true);
+
+export function setup() {
+  return {keyId: client.createDataKey("local", "", {})};
+}
+
+export default (data) => {
+  const start = Date.now();
+  const ciphertext = client.encrypt(`ssn-${__VU}-${__ITER}`, data.keyId, "random");
+  encryptDuration.add(Date.now() - start);
+
+  client.insert("testdb", "patients", {ssn: ciphertext});
+  console.log(`Round trip: ${client.decrypt(ciphertext)}`);
+}
@@ -0,0 +1,8 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export default () => {
+  let ratio = client.estimateMatchRatio("testdb", "testcollection", {locale: "en"}, 1000);
+  console.log(`About ${(ratio * 100).toFixed(1)}% of the documents match`);
+}
@@ -0,0 +1,24 @@
+import xk6_mongo from 'k6/x/mongo';
+import { check } from 'k6';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017/?replicaSet=rs0');
+
+export const options = {
+  scenarios: {
+    before: {executor: 'shared-iterations', iterations: 100, exec: 'workload'},
+    upgrade: {executor: 'shared-iterations', iterations: 1, startTime: '30s', exec: 'upgrade'},
+    after: {executor: 'shared-iterations', iterations: 100, startTime: '40s', exec: 'workload'},
+  },
+};
+
+export function upgrade() {
+  console.log(`FCV before upgrade: ${client.getFCV()}`);
+  client.setFCV("7.0");
+  check(client.getFCV(), {
+    'FCV is 7.0': (v) => v === "7.0",
+  });
+}
+
+export function workload() {
+  client.insert("testdb", "testcollection", {correlationId: `test--mongodb`});
+}
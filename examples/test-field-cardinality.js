@@ -0,0 +1,10 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export default () => {
+  const cardinality = client.fieldCardinality("testdb", "orders", ["customerId", "status", "address.country"], 10000);
+  for (const [field, distinct] of Object.entries(cardinality)) {
+    console.log(`${field}: ~${distinct} distinct values`);
+  }
+}
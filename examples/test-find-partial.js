@@ -0,0 +1,12 @@
+import xk6_mongo from 'k6/x/mongo';
+import { Rate } from 'k6/metrics';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+const partialResults = new Rate('partial_results');
+
+export default () => {
+  // Show whatever arrived within 200ms, like a UI rendering partial results.
+  const result = client.findPartial("testdb", "testcollection", {correlationId: `test--mongodb`}, 200, 50);
+  partialResults.add(result.timedOut);
+  console.log(`Got ${result.documents.length} documents${result.timedOut ? " before timing out" : ""}`);
+}
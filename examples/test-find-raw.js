@@ -0,0 +1,15 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export default () => {
+  const opts = JSON.stringify({
+    showRecordId: true,
+    hint: { _id: 1 },
+    min: { _id: { $oid: "000000000000000000000000" } },
+    limit: 5,
+    maxTimeMS: 1000
+  });
+  let result = client.findRaw("testdb", "testcollection", {}, opts);
+  console.log(JSON.stringify(result));
+}
@@ -0,0 +1,12 @@
+import xk6_mongo from 'k6/x/mongo';
+import { check } from 'k6';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export default () => {
+  const docs = client.findRelaxed("testdb", "testcollection", {correlationId: `test--mongodb`}, {limit: 10});
+  check(docs, {
+    'ids are strings': (d) => d.every((doc) => typeof doc._id === 'string'),
+  });
+  console.log(JSON.stringify(docs));
+}
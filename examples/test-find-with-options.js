@@ -0,0 +1,19 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export default () => {
+  // Force an index either by its name...
+  let byName = client.findWithOptions("testdb", "testcollection", {locale: "en"}, {
+    hint: "locale_1",
+    sort: {time: -1},
+    limit: 10
+  });
+  // ...or by its key specification.
+  let byKeys = client.findWithOptions("testdb", "testcollection", {locale: "en"}, {
+    hint: {locale: 1},
+    projection: {title: 1, locale: 1},
+    limit: 10
+  });
+  console.log(`Found ${byName.length} and ${byKeys.length} documents`);
+}
@@ -0,0 +1,17 @@
+import xk6_mongo from 'k6/x/mongo';
+import { sleep } from 'k6';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export default () => {
+  // Simulate a slow consumer that would otherwise hit the server's idle cursor timeout.
+  const cursor = client.findCursor("testdb", "testcollection", {}, {batchSize: 100, noCursorTimeout: true});
+  try {
+    for (let docs = cursor.next(100); docs.length > 0; docs = cursor.next(100)) {
+      console.log(`Processing ${docs.length} documents`);
+      sleep(5);
+    }
+  } finally {
+    cursor.close();
+  }
+}
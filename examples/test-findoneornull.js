@@ -0,0 +1,12 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export default () => {
+  let result = client.findOneOrNull("testdb", "testcollection", {correlationId: `does-not-exist`});
+  if (result === null) {
+    console.log("No matching document");
+  } else {
+    console.log(JSON.stringify(result));
+  }
+}
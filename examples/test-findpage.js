@@ -0,0 +1,10 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+const pageSize = 20;
+
+export default () => {
+  const page = Math.floor(Math.random() * 5);
+  const result = client.findPage("testdb", "testcollection", {locale: "en"}, [["time", -1]], page * pageSize, pageSize);
+  console.log(`Page ${page + 1} of ${Math.ceil(result.total / pageSize)}: ${result.items.length} items`);
+}
@@ -0,0 +1,12 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+// Roughly central Berlin, as [longitude, latitude] points. The ring does not
+// need to repeat its first point.
+const region = [[13.30, 52.48], [13.48, 52.48], [13.48, 52.56], [13.30, 52.56]];
+
+export default () => {
+  const stores = client.findWithin("testdb", "stores", "location", region);
+  console.log(`Found ${stores.length} stores in the region`);
+}
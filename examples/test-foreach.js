@@ -0,0 +1,12 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export default () => {
+  let count = 0;
+  client.forEach("testdb", "testcollection", {correlationId: `test--mongodb`}, (doc) => {
+    count++;
+    console.log(`Document ${count}: ${JSON.stringify(doc)}`);
+  });
+  console.log(`Visited ${count} documents`);
+}
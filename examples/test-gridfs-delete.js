@@ -0,0 +1,14 @@
+import xk6_mongo from 'k6/x/mongo';
+import { Trend } from 'k6/metrics';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+const deleteDuration = new Trend('gridfs_delete_duration', true);
+
+export default () => {
+  const files = client.gridFSListFiles("testdb", "benchmark", {filename: {$regex: `^blob-${__VU}-`}});
+  for (const file of files) {
+    const start = Date.now();
+    client.gridFSDelete("testdb", "benchmark", file._id.hex());
+    deleteDuration.add(Date.now() - start);
+  }
+}
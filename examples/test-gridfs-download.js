@@ -0,0 +1,13 @@
+import xk6_mongo from 'k6/x/mongo';
+import { check } from 'k6';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export default () => {
+  // -1 is the latest revision, 0 the original upload.
+  const latest = client.gridFSDownloadByName("testdb", "fs", "report.csv", -1);
+  const original = client.gridFSDownloadByName("testdb", "fs", "report.csv", 0);
+  check(null, {
+    'file was overwritten': () => latest.byteLength !== original.byteLength,
+  });
+}
@@ -0,0 +1,12 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+const payload = new Uint8Array(4 * 1024 * 1024).buffer;
+
+// Sweep the chunk size across scenarios, e.g. k6 run -e CHUNK_KB=1024.
+const chunkSize = (parseInt(__ENV.CHUNK_KB) || 255) * 1024;
+
+export default () => {
+  const id = client.gridFSUpload("testdb", "benchmark", `blob-${__VU}-${__ITER}`, payload, chunkSize);
+  console.log(`Uploaded ${id} with ${chunkSize}-byte chunks`);
+}
@@ -0,0 +1,11 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export default () => {
+  const histogram = client.histogramAuto("testdb", "orders", "total", 5, {status: "shipped"});
+  for (const bucket of histogram.buckets) {
+    console.log(`[${bucket.lower}, ${bucket.upper}]: ${bucket.count}`);
+  }
+  console.log(`Missing or non-numeric: ${histogram.other}`);
+}
@@ -0,0 +1,11 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export default () => {
+  const histogram = client.histogram("testdb", "orders", "total", [0, 10, 50, 100, 500], {status: "shipped"});
+  for (const bucket of histogram.buckets) {
+    console.log(`[${bucket.lower}, ${bucket.upper}): ${bucket.count}`);
+  }
+  console.log(`Outside the buckets: ${histogram.other}`);
+}
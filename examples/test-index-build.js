@@ -0,0 +1,20 @@
+import xk6_mongo from 'k6/x/mongo';
+import { sleep } from 'k6';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export function setup() {
+  const build = client.startCreateIndex("testdb", "orders", {customerId: 1, createdAt: -1}, {});
+  let progress = build.progress();
+  while (!progress.done) {
+    console.log(`${build.name()}: ${progress.phase} ${progress.percent.toFixed(1)}%`);
+    sleep(1);
+    progress = build.progress();
+  }
+  // Surface a failed build before measuring anything.
+  build.wait(0);
+}
+
+export default () => {
+  client.find("testdb", "orders", {customerId: 42}, {createdAt: -1}, 10);
+}
@@ -0,0 +1,15 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export default () => {
+  client.find("testdb", "testcollection", {correlationId: `test--mongodb`}, null, 10);
+}
+
+export function teardown() {
+  for (const index of client.indexStats("testdb", "testcollection")) {
+    if (index.ops === 0 && index.name !== "_id_") {
+      console.warn(`Index ${index.name} on ${index.host} was not used since ${index.since}`);
+    }
+  }
+}
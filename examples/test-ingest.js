@@ -0,0 +1,15 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export const options = {vus: 1, iterations: 1};
+
+export default () => {
+  // Insert 500 documents per second, paced on the Go side.
+  const ingest = client.startIngest("testdb", "events", 500);
+  for (let i = 0; i < 15000; i++) {
+    ingest.submit({seq: i, vu: __VU, time: new Date()});
+  }
+  const inserted = ingest.stop();
+  console.log(`Ingested ${inserted} documents`);
+}
@@ -0,0 +1,15 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+// Encode once in the init context so the iterations only pay for the insert.
+const raw = xk6_mongo.encodeBson({
+  correlationId: `test--mongodb`,
+  title: 'Perf test experiment',
+  url: 'example.com',
+  locale: 'en'
+});
+
+export default () => {
+  client.insertRaw("testdb", "testcollection", raw);
+}
@@ -0,0 +1,22 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export function setup() {
+  client.createCollection("testdb", "weather", {
+    timeseries: {timeField: "ts", metaField: "sensor", granularity: "seconds"}
+  });
+}
+
+export default () => {
+  const end = new Date();
+  const start = new Date(end.getTime() - 60 * 60 * 1000);
+  // One measurement per second over the last hour.
+  const inserted = client.insertTimeSeries("testdb", "weather", "sensor", {id: __VU, site: "lab"},
+    start, end, 1000, (ts, i) => ({temperature: 20 + 5 * Math.sin(i / 600), humidity: 40 + Math.random() * 10}));
+  console.log(`Inserted ${inserted} measurements`);
+}
+
+export function teardown() {
+  client.dropCollection("testdb", "weather");
+}
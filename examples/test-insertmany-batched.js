@@ -0,0 +1,13 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export default () => {
+  const docs = [];
+  for (let i = 0; i < 5000; i++) {
+    docs.push({ correlationId: `test--mongodb`, seq: i, time: new Date() });
+  }
+  // 10 batches of 500 documents, 100ms apart.
+  let inserted = client.insertManyBatched("testdb", "testcollection", docs, 500, 100);
+  console.log(`Inserted ${inserted} documents`);
+}
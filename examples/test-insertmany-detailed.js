@@ -0,0 +1,17 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export default () => {
+  const docs = [];
+  for (let i = 0; i < 100; i++) {
+    // Every tenth _id is a duplicate and fails with code 11000.
+    docs.push({_id: `order-${__ITER}-${i - (i % 10 === 9 ? 1 : 0)}`, correlationId: `test--mongodb`});
+  }
+
+  const result = client.insertManyDetailed("testdb", "orders", docs, false);
+  console.log(`Inserted ${result.insertedIds.length} documents`);
+  for (const err of result.writeErrors) {
+    console.log(`Document ${err.index} failed with ${err.code}: ${err.message}`);
+  }
+}
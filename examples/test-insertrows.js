@@ -0,0 +1,21 @@
+import xk6_mongo from 'k6/x/mongo';
+import { SharedArray } from 'k6/data';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+const users = new SharedArray('users', () => {
+  const rows = [];
+  for (let i = 0; i < 10000; i++) {
+    rows.push({ userId: `user-${i}`, locale: i % 2 ? 'en' : 'de', score: i });
+  }
+  return rows;
+});
+
+export function setup() {
+  let inserted = client.insertRows("testdb", "users", users, 2000);
+  console.log(`Seeded ${inserted} users`);
+}
+
+export default () => {
+  client.findOne("testdb", "users", {userId: "user-42"});
+}
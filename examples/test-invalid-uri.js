@@ -0,0 +1,13 @@
+import xk6_mongo from 'k6/x/mongo';
+
+let client;
+try {
+  client = xk6_mongo.newClient(__ENV.MONGODB_URI || "");
+} catch (e) {
+  // e.g. invalid connection URI: error parsing uri: scheme must be "mongodb" or "mongodb+srv"
+  throw new Error(`Cannot create MongoDB client, set MONGODB_URI: ${e}`);
+}
+
+export default () => {
+  client.insert("testdb", "testcollection", {correlationId: `test--mongodb`});
+}
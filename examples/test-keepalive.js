@@ -0,0 +1,21 @@
+import xk6_mongo from 'k6/x/mongo';
+import { sleep } from 'k6';
+
+export const options = {
+  stages: [
+    { duration: '30s', target: 10 },
+    { duration: '2m', target: 0 },
+    { duration: '30s', target: 10 },
+  ],
+};
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017', {
+  minPoolSize: 5,
+  maxConnIdleTimeMS: 60000,
+  keepAliveIntervalMS: 20000,
+});
+
+export default () => {
+  client.findOne("testdb", "testcollection", {correlationId: `test--mongodb`});
+  sleep(1);
+}
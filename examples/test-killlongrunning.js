@@ -0,0 +1,25 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export const options = {
+  scenarios: {
+    app: {executor: 'constant-vus', vus: 10, duration: '1m', exec: 'query'},
+    chaos: {executor: 'constant-arrival-rate', rate: 1, timeUnit: '5s', duration: '1m', preAllocatedVUs: 1, exec: 'chaos'},
+  },
+};
+
+export function query() {
+  try {
+    client.aggregate("testdb", "testcollection", [{$match: {$expr: {$gt: [{$strLenCP: "$title"}, 3]}}}]);
+  } catch (e) {
+    console.log(`Query was killed: ${e}`);
+  }
+}
+
+export function chaos() {
+  const killed = client.killLongRunning(500);
+  if (killed.length > 0) {
+    console.log(`Killed operations ${killed.join(", ")}`);
+  }
+}
@@ -0,0 +1,17 @@
+import xk6_mongo from 'k6/x/mongo';
+import { Trend } from 'k6/metrics';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+const commandTime = new Trend('mongo_command_time', true);
+const clientOverhead = new Trend('mongo_client_overhead', true);
+
+export default () => {
+  const start = Date.now();
+  client.findOne("testdb", "testcollection", {correlationId: `test--mongodb`});
+  const wall = Date.now() - start;
+
+  // Round trip of the find command: server time plus network transfer.
+  const command = client.lastServerDuration();
+  commandTime.add(command);
+  clientOverhead.add(Math.max(wall - command, 0));
+}
@@ -0,0 +1,17 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+client.setMaxDocBytes(1024 * 1024);
+
+export default () => {
+  const doc = {
+    correlationId: `test--mongodb`,
+    payload: "x".repeat(Math.floor(Math.random() * 2 * 1024 * 1024)),
+  };
+  try {
+    client.insert("testdb", "testcollection", doc);
+  } catch (e) {
+    // e.g. document of 1500042 bytes exceeds maxDocBytes of 1048576, largest fields: payload (1500011 bytes), ...
+    console.log(`Rejected document: ${e}`);
+  }
+}
@@ -0,0 +1,21 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export function setup() {
+  // $merge on custom fields requires a unique index on them.
+  client.createIndex("reportdb", "daily_totals", {day: 1, locale: 1}, {unique: true});
+}
+
+export default () => {
+  const pipeline = [
+    {$match: {correlationId: "test--mongodb"}},
+    {$group: {_id: {day: {$dateTrunc: {date: "$createdAt", unit: "day"}}, locale: "$locale"}, total: {$sum: "$amount"}}},
+    {$project: {_id: 0, day: "$_id.day", locale: "$_id.locale", total: 1}},
+  ];
+
+  // Add the new totals to the running totals of matching days.
+  const whenMatched = [{$set: {total: {$add: ["$total", "$$new.total"]}}}];
+
+  client.merge("testdb", "testcollection", pipeline, "reportdb", "daily_totals", whenMatched, "insert", ["day", "locale"]);
+}
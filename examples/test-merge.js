@@ -0,0 +1,13 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export default () => {
+  const pipeline = [
+    { $match: { correlationId: "test--mongodb" } },
+    { $group: { _id: "$locale", count: { $sum: 1 } } }
+  ];
+
+  client.merge("testdb", "testcollection", pipeline, "reportdb", "locales", "replace", "insert");
+  console.log(`reportdb.locales holds ${client.countDocuments("reportdb", "locales", {})} locales`);
+}
@@ -0,0 +1,12 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017', { poolMetrics: true, commandMetrics: true, maxPoolSize: 10 });
+
+export const options = {
+  vus: 20,
+  duration: '10s',
+};
+
+export default () => {
+  client.findOne("testdb", "testcollection", {correlationId: `test--mongodb`});
+}
@@ -0,0 +1,12 @@
+import xk6_mongo from 'k6/x/mongo';
+import { Trend } from 'k6/metrics';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017/?replicaSet=rs0');
+const nodeLatency = new Trend('mongo_node_latency', true);
+
+export default () => {
+  const latencies = client.nodeLatencies();
+  for (const [host, ms] of Object.entries(latencies)) {
+    nodeLatency.add(ms, {host: host});
+  }
+}
@@ -0,0 +1,13 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+client.setCoerceNumbers(true);
+
+export default () => {
+  // Beyond 2^53, so the value is given as a string.
+  const byLong = client.find("testdb", "accounts", xk6_mongo.longFilter("accountNo", "9007199254740993"));
+  const byDecimal = client.find("testdb", "accounts", xk6_mongo.decimalFilter("balance", "19.99"));
+  // Extended JSON wrappers, e.g. taken from an exported document.
+  const byWrapper = client.find("testdb", "accounts", {accountNo: {$numberLong: "9007199254740993"}});
+  console.log(`Matched ${byLong.length}, ${byDecimal.length} and ${byWrapper.length} documents`);
+}
@@ -0,0 +1,13 @@
+import xk6_mongo from 'k6/x/mongo';
+import { Trend } from 'k6/metrics';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+const documentAge = new Trend('document_age_seconds');
+
+export default () => {
+  const docs = client.find("testdb", "testcollection", {correlationId: `test--mongodb`}, null, 100);
+  for (const doc of docs) {
+    const created = xk6_mongo.objectIdTimestamp(doc._id.hex());
+    documentAge.add(Date.now() / 1000 - created.unix());
+  }
+}
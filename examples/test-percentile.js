@@ -0,0 +1,8 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export default () => {
+  const [p50, p95, p99] = client.percentile("testdb", "requests", "latencyMs", [0.5, 0.95, 0.99], {service: "checkout"});
+  console.log(`Latency p50=${p50}ms p95=${p95}ms p99=${p99}ms`);
+}
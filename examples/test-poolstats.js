@@ -0,0 +1,17 @@
+import xk6_mongo from 'k6/x/mongo';
+import { Trend } from 'k6/metrics';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017', {maxPoolSize: 10});
+const inUse = new Trend('pool_in_use');
+
+export default async () => {
+  // Several operations in flight per VU check out several connections.
+  const pending = [];
+  for (let i = 0; i < 20; i++) {
+    pending.push(client.findOneAsync("testdb", "testcollection", {correlationId: `test--mongodb`}));
+  }
+  const stats = client.poolStats();
+  inUse.add(stats.inUse);
+  console.log(`Connections: ${stats.total} open, ${stats.inUse} in use, ${stats.idle} idle`);
+  await Promise.all(pending);
+}
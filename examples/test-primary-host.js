@@ -0,0 +1,11 @@
+import xk6_mongo from 'k6/x/mongo';
+import exec from 'k6/execution';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017/?replicaSet=rs0');
+
+export default () => {
+  // Pass the address to the fault injection tooling, e.g. through a tag.
+  const primary = client.primaryHost();
+  exec.vu.tags['primary'] = primary;
+  client.insert("testdb", "testcollection", {correlationId: `test--mongodb`, primary: primary});
+}
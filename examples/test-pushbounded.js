@@ -0,0 +1,9 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export default () => {
+  // Keep only the 10 most recent events on the document.
+  client.pushBounded("testdb", "testcollection", {correlationId: `test--mongodb`}, "lastEvents",
+    { type: "login", at: new Date() }, 10);
+}
@@ -0,0 +1,12 @@
+import xk6_mongo from 'k6/x/mongo';
+import { check } from 'k6';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export default () => {
+  const stats = client.queryStats("testdb", "testcollection", {correlationId: `test--mongodb`});
+  check(stats, {
+    'query uses an index': (s) => s.docsExamined <= s.nReturned,
+  });
+  console.log(`examined ${stats.keysExamined} keys and ${stats.docsExamined} docs in ${stats.executionTimeMS}ms`);
+}
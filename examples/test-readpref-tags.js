@@ -0,0 +1,17 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017/?replicaSet=rs0');
+
+// Prefer a secondary in the local region, falling back to any secondary.
+const localRead = {
+  mode: "secondary",
+  tags: [{region: __ENV.REGION || "us-east"}, {}],
+};
+
+export default () => {
+  const docs = client.findWithOptions("testdb", "testcollection", {correlationId: `test--mongodb`}, {
+    limit: 10,
+    readPreference: localRead,
+  });
+  console.log(`Read ${docs.length} documents from the local region`);
+}
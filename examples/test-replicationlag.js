@@ -0,0 +1,20 @@
+import xk6_mongo from 'k6/x/mongo';
+import { Trend } from 'k6/metrics';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017/?replicaSet=rs0');
+const replicationLag = new Trend('replication_lag', true);
+
+export const options = {
+  scenarios: {
+    writers: {executor: 'constant-vus', vus: 20, duration: '1m', exec: 'write'},
+    monitor: {executor: 'constant-arrival-rate', rate: 1, timeUnit: '1s', duration: '1m', preAllocatedVUs: 1, exec: 'monitor'},
+  },
+};
+
+export function write() {
+  client.insert("testdb", "testcollection", {correlationId: `test--mongodb`, time: new Date()});
+}
+
+export function monitor() {
+  replicationLag.add(client.replicationLag());
+}
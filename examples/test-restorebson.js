@@ -0,0 +1,13 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export function setup() {
+  // Written by `mongodump --db shop --collection orders`.
+  const restored = client.restoreBSON("testdb", "orders", "./dump/shop/orders.bson");
+  console.log(`Restored ${restored} orders`);
+}
+
+export default () => {
+  client.find("testdb", "orders", {status: "shipped"}, null, 20);
+}
@@ -0,0 +1,13 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017/?replicaSet=rs0');
+// Retry up to 5 times, waiting 100ms, 200ms, 400ms, ... in between, so that
+// a primary election during the soak test does not fail the iteration. The
+// insert is left to the driver's retryable writes; setRetryWrites(true) would
+// retry it here as well.
+client.setRetry(5, 100);
+
+export default () => {
+  client.insert("testdb", "testcollection", {correlationId: `test--mongodb`, time: new Date()});
+  client.findOne("testdb", "testcollection", {correlationId: `test--mongodb`});
+}
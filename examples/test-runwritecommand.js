@@ -0,0 +1,15 @@
+import xk6_mongo from 'k6/x/mongo';
+import { check } from 'k6';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export default () => {
+  const reply = client.runWriteCommand("testdb", "insert", "testcollection", {
+    documents: [{_id: "dup"}, {_id: "dup"}, {_id: `unique-${__VU}-${__ITER}`}],
+    ordered: false,
+  });
+  check(reply, {
+    'non-duplicates were inserted': (r) => r.n >= 1,
+    'duplicate reported as write error': (r) => (r.writeErrors || []).some((e) => e.code === 11000),
+  });
+}
@@ -0,0 +1,17 @@
+import xk6_mongo from 'k6/x/mongo';
+
+// Requires an Atlas cluster with a search index named "default" on sample_mflix.movies.
+const client = xk6_mongo.newClient(__ENV.MONGODB_URI);
+
+const terms = ["space", "love", "war", "robot", "ocean"];
+
+export default () => {
+  const term = terms[Math.floor(Math.random() * terms.length)];
+  const movies = client.search("sample_mflix", "movies", "default", {
+    text: {query: term, path: ["title", "plot"], fuzzy: {maxEdits: 1}},
+  }, [
+    {$limit: 10},
+    {$project: {title: 1, score: {$meta: "searchScore"}}},
+  ]);
+  console.log(`Found ${movies.length} movies for "${term}"`);
+}
@@ -0,0 +1,17 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export function setup() {
+  // "$i" is the sequence number of each generated document.
+  client.seedDocuments("testdb", "products", 1000000, {
+    sku: {$concat: ["sku-", {$toString: "$i"}]},
+    price: {$round: [{$multiply: [{$rand: {}}, 100]}, 2]},
+    category: {$arrayElemAt: [["books", "games", "music"], {$mod: ["$i", 3]}]},
+  });
+}
+
+export default () => {
+  const sku = `sku-${Math.floor(Math.random() * 1000000)}`;
+  client.findOne("testdb", "products", {sku: sku});
+}
@@ -0,0 +1,15 @@
+import xk6_mongo from 'k6/x/mongo';
+
+// Connect through mongos.
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export default () => {
+  client.insert("testdb", "events", {userId: `user-${__VU}`, time: new Date()});
+}
+
+export function teardown() {
+  const distribution = client.shardDistribution("testdb", "events");
+  for (const [shard, count] of Object.entries(distribution)) {
+    console.log(`${shard}: ${count} documents`);
+  }
+}
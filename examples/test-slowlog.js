@@ -0,0 +1,9 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+// Logs e.g. level=warning msg="Slow MongoDB command" command=find durationMs=312.4 filter="{\"locale\":\"?\"}"
+client.setSlowLogThreshold(250);
+
+export default () => {
+  client.find("testdb", "testcollection", {locale: "en"}, null, 100);
+}
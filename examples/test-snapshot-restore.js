@@ -0,0 +1,28 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export const options = {
+  scenarios: {
+    checkout: {executor: 'shared-iterations', iterations: 1000, exec: 'checkout'},
+    reset: {executor: 'shared-iterations', iterations: 1, startTime: '1m', exec: 'reset'},
+    refunds: {executor: 'shared-iterations', iterations: 1000, startTime: '1m10s', exec: 'refund'},
+  },
+};
+
+export function setup() {
+  // Take the baseline once the slow seed has run.
+  client.snapshot("testdb", "accounts");
+}
+
+export function checkout() {
+  client.updateOne("testdb", "accounts", {_id: `acc-${__ITER % 100}`}, {$inc: {balance: -10}});
+}
+
+export function reset() {
+  client.restore("testdb", "accounts");
+}
+
+export function refund() {
+  client.updateOne("testdb", "accounts", {_id: `acc-${__ITER % 100}`}, {$inc: {balance: 10}});
+}
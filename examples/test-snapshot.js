@@ -0,0 +1,28 @@
+import xk6_mongo from 'k6/x/mongo';
+import { check } from 'k6';
+
+// Snapshot reads require a replica set or sharded cluster running MongoDB 5.0+.
+const client = xk6_mongo.newClient('mongodb://localhost:27017/?replicaSet=rs0');
+
+export const options = {
+  scenarios: {
+    writer: {executor: 'constant-vus', vus: 5, duration: '30s', exec: 'write'},
+    reader: {executor: 'constant-vus', vus: 1, duration: '30s', exec: 'read'},
+  },
+};
+
+export function write() {
+  client.insert("testdb", "events", {correlationId: `test--mongodb`, ts: new Date()});
+}
+
+export function read() {
+  const at = client.clusterTime();
+  let first, second;
+  client.withSnapshot(at, (snap) => {
+    first = snap.find("testdb", "events", {correlationId: `test--mongodb`}, null, 0).length;
+    second = snap.find("testdb", "events", {correlationId: `test--mongodb`}, null, 0).length;
+  });
+  check(null, {
+    'both reads see the same snapshot': () => first === second,
+  });
+}
@@ -0,0 +1,32 @@
+import xk6_mongo from 'k6/x/mongo';
+import { check } from 'k6';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export const options = {
+  scenarios: {
+    publish: {executor: 'constant-arrival-rate', rate: 50, duration: '30s', preAllocatedVUs: 5, exec: 'publish'},
+    consume: {executor: 'constant-vus', vus: 2, duration: '30s', exec: 'consume'},
+  },
+};
+
+export function setup() {
+  client.dropCollection("testdb", "messages");
+  client.createCollection("testdb", "messages", {capped: true, size: 16 * 1024 * 1024, max: 10000});
+}
+
+export function publish() {
+  client.insert("testdb", "messages", {topic: "orders", sentAt: Date.now()});
+}
+
+let tail;
+
+export function consume() {
+  if (!tail) {
+    tail = client.tailCapped("testdb", "messages", {topic: "orders"});
+  }
+  const msg = tail.next(1000);
+  check(msg, {
+    'message received': (m) => m !== null,
+  });
+}
@@ -0,0 +1,14 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+// Every operation below fails if it takes longer than 200ms.
+client.setTimeout(200);
+
+export default () => {
+  try {
+    const docs = client.find("testdb", "testcollection", {correlationId: `test--mongodb`}, null, 100);
+    console.log(`Found ${docs.length} documents within the SLA`);
+  } catch (e) {
+    console.error(`Operation exceeded the SLA: ${e}`);
+  }
+}
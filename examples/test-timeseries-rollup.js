@@ -0,0 +1,24 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export function setup() {
+  client.createCollection("testdb", "events", {
+    timeseries: {timeField: "ts", metaField: "sensor", granularity: "seconds"},
+  });
+}
+
+export default () => {
+  client.insert("testdb", "events", {ts: new Date(), sensor: `s-${__VU}`, value: Math.random() * 100});
+}
+
+export function teardown() {
+  // Roll raw events up into per-minute averages in a time-series collection.
+  client.aggregateOut("testdb", "events", [
+    {$group: {
+      _id: {sensor: "$sensor", minute: {$dateTrunc: {date: "$ts", unit: "minute"}}},
+      avg: {$avg: "$value"},
+    }},
+    {$project: {_id: 0, sensor: "$_id.sensor", ts: "$_id.minute", avg: 1}},
+  ], "testdb", "events_per_minute", {timeField: "ts", metaField: "sensor", granularity: "minutes"});
+}
@@ -0,0 +1,19 @@
+import xk6_mongo from 'k6/x/mongo';
+import { Trend } from 'k6/metrics';
+
+// Requires a replica set; step down the primary while the test runs, e.g.
+// `rs.stepDown()` from mongosh.
+const client = xk6_mongo.newClient('mongodb://localhost:27017/?replicaSet=rs0');
+const recovery = new Trend('primary_recovery_time', true);
+
+export const options = {
+  iterations: 1,
+};
+
+export default () => {
+  const start = Date.now();
+  client.onTopologyChange((primary) => {
+    recovery.add(Date.now() - start);
+    console.log(`New primary: ${primary}`);
+  });
+}
@@ -0,0 +1,18 @@
+import xk6_mongo from 'k6/x/mongo';
+
+// Transactions require a replica set or sharded cluster.
+const client = xk6_mongo.newClient('mongodb://localhost:27017/?replicaSet=rs0');
+
+export default () => {
+  const txnOptions = {
+    readConcern: "snapshot",
+    writeConcern: "majority"
+  };
+
+  client.withTransaction(txnOptions, (tx) => {
+    const account = tx.findOne("testdb", "accounts", {_id: "alice"});
+    tx.updateOne("testdb", "accounts", {_id: "alice"}, {$inc: {balance: -10}});
+    tx.updateOne("testdb", "accounts", {_id: "bob"}, {$inc: {balance: 10}});
+    console.log(`Balance before transfer: ${account.balance}`);
+  });
+}
@@ -0,0 +1,10 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export default () => {
+  client.updateManyPipeline("testdb", "orders", {status: "open"}, [
+    {$set: {total: {$add: ["$subtotal", "$tax", "$shipping"]}}},
+    {$set: {updatedAt: "$$NOW"}},
+  ]);
+}
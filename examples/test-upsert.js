@@ -15,13 +15,13 @@ export function setup() {
     time: `${new Date(Date.now()).toISOString()}`
   };
   
-  let error = client.insert(db, col, doc);
-  if (error) 
-    console.log(error.message);
+  client.insert(db, col, doc);
 }
 
 export default () => {
-  let error = client.upsert(db, col, {update_id: id}, {$set: {locale: 'en', title: 'This is a new document'}})
-  if (error) 
-    console.log(error.message);
+  let result = client.upsert(db, col, {update_id: id}, {$set: {locale: 'en', title: 'This is a new document'}})
+  if (result.upsertedCount > 0)
+    console.log(`Inserted new document ${result.upsertedId}`);
+  else
+    console.log(`Updated ${result.modifiedCount} existing document(s)`);
 }
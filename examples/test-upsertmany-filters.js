@@ -0,0 +1,15 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export default () => {
+  const pairs = [];
+  for (let i = 0; i < 100; i++) {
+    pairs.push({
+      query: {externalId: `ext-${__VU}-${i}`},
+      update: {$set: {syncedAt: new Date(), version: __ITER}},
+    });
+  }
+  const result = client.upsertManyWithFilters("testdb", "synced", pairs, false);
+  console.log(`matched=${result.matchedCount} modified=${result.modifiedCount} upserted=${result.upsertedCount}`);
+}
@@ -0,0 +1,33 @@
+import xk6_mongo from 'k6/x/mongo';
+import { check } from 'k6';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export function setup() {
+  client.dropCollection("testdb", "customers");
+  client.createCollection("testdb", "customers", {
+    validator: {
+      $jsonSchema: {
+        bsonType: "object",
+        required: ["name", "email"],
+        properties: {
+          name: {bsonType: "string"},
+          age: {bsonType: "int", minimum: 0},
+        },
+      },
+    },
+  });
+}
+
+function generateCustomer() {
+  return {name: `customer-${__VU}-${__ITER}`, email: `c${__ITER}@example.com`};
+}
+
+export default () => {
+  const doc = generateCustomer();
+  const valid = client.validateAgainstSchema("testdb", "customers", doc);
+  check(valid, {'generated customer matches the schema': (v) => v});
+  if (valid) {
+    client.insert("testdb", "customers", doc);
+  }
+}
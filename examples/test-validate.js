@@ -0,0 +1,11 @@
+import xk6_mongo from 'k6/x/mongo';
+import { check } from 'k6';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export default () => {
+  const report = client.validateCollection("testdb", "testcollection", false);
+  check(report, {
+    'collection is valid': (r) => r.valid === true,
+  });
+}
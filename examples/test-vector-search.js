@@ -0,0 +1,16 @@
+import xk6_mongo from 'k6/x/mongo';
+import { SharedArray } from 'k6/data';
+
+// Requires an Atlas cluster with a vector search index on plot_embedding.
+const client = xk6_mongo.newClient(__ENV.MONGODB_URI);
+
+// Precomputed query embeddings, e.g. from the embedding model used for the data.
+const queries = new SharedArray('queries', () => JSON.parse(open('./query-embeddings.json')));
+
+export default () => {
+  const vector = queries[Math.floor(Math.random() * queries.length)];
+  const movies = client.vectorSearch("sample_mflix", "embedded_movies", "vector_index", "plot_embedding", vector, 10, {year: {$gte: 2000}});
+  for (const movie of movies) {
+    console.log(`${movie.title}: ${movie.score}`);
+  }
+}
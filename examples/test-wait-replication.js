@@ -0,0 +1,14 @@
+import xk6_mongo from 'k6/x/mongo';
+import { Trend } from 'k6/metrics';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017/?replicaSet=rs0');
+const propagation = new Trend('replication_propagation', true);
+
+export default () => {
+  const id = `repl-${__VU}-${__ITER}`;
+  client.insert("testdb", "testcollection", {_id: id, correlationId: `test--mongodb`});
+
+  // Time until the document is readable on two secondaries.
+  const ms = client.waitForReplication("testdb", "testcollection", {_id: id}, 2, 5000);
+  propagation.add(ms);
+}
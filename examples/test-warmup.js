@@ -0,0 +1,10 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017', { maxPoolSize: 20 });
+
+// Each VU warms up its own client before the measured phase starts.
+client.warmUp(20);
+
+export default () => {
+  client.findOne("testdb", "testcollection", {correlationId: `test--mongodb`});
+}
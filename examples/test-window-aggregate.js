@@ -0,0 +1,15 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export default () => {
+  const results = client.windowAggregate("testdb", "readings", [
+    {$setWindowFields: {
+      partitionBy: "$sensor",
+      sortBy: {time: 1},
+      output: {movingAvg: {$avg: "$value", window: {documents: [-5, 0]}}}
+    }},
+    {$limit: 100}
+  ]);
+  console.log(`Computed ${results.length} moving averages`);
+}
@@ -0,0 +1,12 @@
+import xk6_mongo from 'k6/x/mongo';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+
+export function setup() {
+  const v = client.wireVersion();
+  console.log(`driver ${v.driverMin}-${v.driverMax}, server ${v.serverMin}-${v.serverMax}, using ${v.negotiated}`);
+}
+
+export default () => {
+  client.findOne("testdb", "testcollection", {correlationId: `test--mongodb`});
+}
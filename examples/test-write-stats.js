@@ -0,0 +1,23 @@
+import xk6_mongo from 'k6/x/mongo';
+import { Trend } from 'k6/metrics';
+
+const client = xk6_mongo.newClient('mongodb://localhost:27017');
+const writeAmplification = new Trend('write_amplification');
+
+export default () => {
+  const before = client.writeStats("testdb", "ingest");
+  const docs = [];
+  for (let i = 0; i < 1000; i++) {
+    docs.push({correlationId: `test--mongodb`, seq: i, payload: "x".repeat(512)});
+  }
+  client.insertMany("testdb", "ingest", docs);
+  // WiredTiger writes to disk on checkpoints, so measure over a longer
+  // window than this to get stable figures.
+  const after = client.writeStats("testdb", "ingest");
+
+  const logical = after.size - before.size;
+  if (logical > 0) {
+    writeAmplification.add((after.bytesWritten - before.bytesWritten) / logical);
+  }
+  console.log(`Pages evicted: ${after.pagesEvicted - before.pagesEvicted}`);
+}
@@ -0,0 +1,79 @@
+package xk6_mongo
+
+import (
+	"os"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestExprFilters(t *testing.T) {
+	uri := os.Getenv("MONGODB_URI")
+	if uri == "" {
+		t.Skip("MONGODB_URI not set")
+	}
+
+	client, err := new(Mongo).NewClient(uri)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	defer client.Disconnect()
+
+	db := "exprtestdb"
+	col := "exprtestcol"
+	if err := client.DropCollection(db, col); err != nil {
+		t.Fatalf("drop: %v", err)
+	}
+	docs := []any{
+		bson.M{"_id": "expr-1", "spent": 120, "budget": 100},
+		bson.M{"_id": "expr-2", "spent": 80, "budget": 100},
+		bson.M{"_id": "expr-3", "spent": 150, "budget": 200},
+		bson.M{"_id": "expr-4", "spent": 300, "budget": 250},
+	}
+	if err := client.InsertMany(db, col, docs); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+
+	// Documents that spent more than their budget: expr-1 and expr-4.
+	overBudget := map[string]any{
+		"$expr": map[string]any{"$gt": []any{"$spent", "$budget"}},
+	}
+
+	found, err := client.Find(db, col, overBudget, bson.M{"_id": 1}, 0)
+	if err != nil {
+		t.Fatalf("find: %v", err)
+	}
+	if len(found) != 2 || found[0]["_id"] != "expr-1" || found[1]["_id"] != "expr-4" {
+		t.Fatalf("unexpected find result %v", found)
+	}
+
+	count, err := client.CountDocuments(db, col, overBudget)
+	if err != nil {
+		t.Fatalf("count: %v", err)
+	}
+	if count != 2 {
+		t.Fatalf("expected 2 documents, got %d", count)
+	}
+
+	if err := client.UpdateMany(db, col, overBudget, bson.M{"flagged": true}); err != nil {
+		t.Fatalf("update many: %v", err)
+	}
+	flagged, err := client.CountDocuments(db, col, bson.M{"flagged": true})
+	if err != nil {
+		t.Fatalf("count flagged: %v", err)
+	}
+	if flagged != 2 {
+		t.Fatalf("expected 2 flagged documents, got %d", flagged)
+	}
+
+	if err := client.DeleteMany(db, col, overBudget); err != nil {
+		t.Fatalf("delete many: %v", err)
+	}
+	remaining, err := client.CountDocuments(db, col, bson.M{})
+	if err != nil {
+		t.Fatalf("count remaining: %v", err)
+	}
+	if remaining != 2 {
+		t.Fatalf("expected 2 remaining documents, got %d", remaining)
+	}
+}
@@ -0,0 +1,67 @@
+package xk6_mongo
+
+import (
+	"fmt"
+	"log"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// FindWithin returns the documents whose GeoJSON field lies within polygon,
+// given as a list of [longitude, latitude] points, using $geoWithin with a
+// $geometry. The ring is closed automatically when its last point does not
+// repeat the first.
+func (c *Client) FindWithin(database string, collection string, field string, polygon [][]float64) ([]bson.M, error) {
+	ctx, cancel := c.operationContext()
+	defer cancel()
+
+	geometry, err := geoPolygon(polygon)
+	if err != nil {
+		return nil, err
+	}
+	filter := bson.D{{Key: field, Value: bson.D{
+		{Key: "$geoWithin", Value: bson.D{{Key: "$geometry", Value: geometry}}},
+	}}}
+
+	col := c.client.Database(database).Collection(collection)
+	cur, err := withRetry(ctx, c, func() (*mongo.Cursor, error) { return col.Find(ctx, filter, c.findOptions()) })
+	if err != nil {
+		log.Printf("Error while finding documents within polygon: %v", err)
+		return nil, err
+	}
+	var results []bson.M
+	if err = cur.All(ctx, &results); err != nil {
+		log.Printf(errDecodingDocuments, err)
+		return nil, err
+	}
+	return results, nil
+}
+
+// geoPolygon validates the points of a polygon ring and returns it as a
+// GeoJSON Polygon, closing the ring if needed.
+func geoPolygon(points [][]float64) (bson.D, error) {
+	ring := make(bson.A, 0, len(points)+1)
+	for i, point := range points {
+		if len(point) != 2 {
+			return nil, fmt.Errorf("polygon point %d must be a [longitude, latitude] pair", i)
+		}
+		if point[0] < -180 || point[0] > 180 || point[1] < -90 || point[1] > 90 {
+			return nil, fmt.Errorf("polygon point %d %v is out of range", i, point)
+		}
+		ring = append(ring, bson.A{point[0], point[1]})
+	}
+	if len(points) > 0 {
+		first, last := points[0], points[len(points)-1]
+		if first[0] != last[0] || first[1] != last[1] {
+			ring = append(ring, bson.A{first[0], first[1]})
+		}
+	}
+	if len(ring) < 4 {
+		return nil, fmt.Errorf("polygon needs at least 3 distinct points, got %d", len(points))
+	}
+	return bson.D{
+		{Key: "type", Value: "Polygon"},
+		{Key: "coordinates", Value: bson.A{ring}},
+	}, nil
+}
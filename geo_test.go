@@ -0,0 +1,25 @@
+package xk6_mongo
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestGeoPolygon(t *testing.T) {
+	polygon, err := geoPolygon([][]float64{{0, 0}, {10, 0}, {10, 10}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ring := polygon[1].Value.(bson.A)[0].(bson.A)
+	if len(ring) != 4 {
+		t.Fatalf("expected the ring to be closed, got %v", ring)
+	}
+
+	if _, err := geoPolygon([][]float64{{0, 0}, {10, 0}, {0, 0}}); err == nil {
+		t.Fatalf("expected error for a polygon with two distinct points")
+	}
+	if _, err := geoPolygon([][]float64{{0, 0}, {200, 0}, {10, 10}}); err == nil {
+		t.Fatalf("expected error for an out of range longitude")
+	}
+}
@@ -0,0 +1,136 @@
+package xk6_mongo
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// gridFSBucket opens a GridFS bucket of database, with the default name "fs"
+// when bucket is empty and the default chunk size of 255KB when chunkSize is
+// not positive. The driver's GridFS streams take deadlines rather than
+// contexts, so the client's default timeout is applied through those.
+func (c *Client) gridFSBucket(database string, bucket string, chunkSize int32) (*gridfs.Bucket, error) {
+	opts := options.GridFSBucket()
+	if bucket != "" {
+		opts.SetName(bucket)
+	}
+	if chunkSize > 0 {
+		opts.SetChunkSizeBytes(chunkSize)
+	}
+	b, err := gridfs.NewBucket(c.client.Database(database), opts)
+	if err != nil {
+		return nil, err
+	}
+	if c.timeout > 0 {
+		deadline := time.Now().Add(c.timeout)
+		if err := b.SetReadDeadline(deadline); err != nil {
+			return nil, err
+		}
+		if err := b.SetWriteDeadline(deadline); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// GridFSUpload stores data as a new file named filename in the given GridFS
+// bucket, split into chunks of chunkSizeBytes (255KB when 0), and returns the
+// file's id as a hex string. Uploading under an existing name adds a revision.
+func (c *Client) GridFSUpload(database string, bucket string, filename string, data []byte, chunkSizeBytes int32) (string, error) {
+	if c.dryRun("gridFSUpload", database, bucket, "filename", filename, "bytes", len(data)) {
+		return "", nil
+	}
+
+	b, err := c.gridFSBucket(database, bucket, chunkSizeBytes)
+	if err != nil {
+		log.Printf("Error while opening GridFS bucket: %v", err)
+		return "", err
+	}
+	id, err := b.UploadFromStream(filename, bytes.NewReader(data))
+	if err != nil {
+		log.Printf("Error while uploading GridFS file %q: %v", filename, err)
+		return "", err
+	}
+	return id.Hex(), nil
+}
+
+// GridFSDownloadByName downloads the content of a file stored in the given
+// GridFS bucket, "fs" when empty, by name. Since a file may have been uploaded
+// several times under the same name, revision selects which upload is read:
+// 0 is the original, 1 the first revision and so on, while -1 is the most
+// recent, -2 the one before it and so on.
+func (c *Client) GridFSDownloadByName(database string, bucket string, filename string, revision int) ([]byte, error) {
+	b, err := c.gridFSBucket(database, bucket, 0)
+	if err != nil {
+		log.Printf("Error while opening GridFS bucket: %v", err)
+		return nil, err
+	}
+	var buf bytes.Buffer
+	opts := options.GridFSName().SetRevision(int32(revision))
+	if _, err := b.DownloadToStreamByName(filename, &buf, opts); err != nil {
+		log.Printf("Error while downloading GridFS file %q: %v", filename, err)
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GridFSDelete deletes the file with the given hex id, along with its chunks,
+// from a GridFS bucket.
+func (c *Client) GridFSDelete(database string, bucket string, fileId string) error {
+	id, err := primitive.ObjectIDFromHex(fileId)
+	if err != nil {
+		return fmt.Errorf("invalid GridFS file id %q: %w", fileId, err)
+	}
+	if c.dryRun("gridFSDelete", database, bucket, "id", fileId) {
+		return nil
+	}
+
+	ctx, cancel := c.operationContext()
+	defer cancel()
+	b, err := c.gridFSBucket(database, bucket, 0)
+	if err != nil {
+		log.Printf("Error while opening GridFS bucket: %v", err)
+		return err
+	}
+	if err := b.DeleteContext(ctx, id); err != nil {
+		log.Printf("Error while deleting GridFS file %s: %v", fileId, err)
+		return err
+	}
+	return nil
+}
+
+// GridFSListFiles returns the metadata documents (_id, filename, length,
+// chunkSize, uploadDate, ...) of the files in a GridFS bucket that match
+// filter.
+func (c *Client) GridFSListFiles(database string, bucket string, filter any) ([]bson.M, error) {
+	filter = c.coerceFilter(filter)
+	ctx, cancel := c.operationContext()
+	defer cancel()
+
+	if filter == nil {
+		filter = bson.D{}
+	}
+	b, err := c.gridFSBucket(database, bucket, 0)
+	if err != nil {
+		log.Printf("Error while opening GridFS bucket: %v", err)
+		return nil, err
+	}
+	cur, err := b.FindContext(ctx, filter)
+	if err != nil {
+		log.Printf("Error while listing GridFS files: %v", err)
+		return nil, err
+	}
+	var files []bson.M
+	if err = cur.All(ctx, &files); err != nil {
+		log.Printf(errDecodingDocuments, err)
+		return nil, err
+	}
+	return files, nil
+}
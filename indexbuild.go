@@ -0,0 +1,215 @@
+package xk6_mongo
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+// IndexBuild is a handle on an index build started with StartCreateIndex.
+type IndexBuild struct {
+	client     *Client
+	database   string
+	collection string
+	name       string
+	done       chan struct{}
+
+	mu  sync.Mutex
+	err error
+}
+
+// IndexBuildProgress is the state of an index build. Phase is the server's
+// description of the current phase, such as "Index Build: scanning
+// collection", and Processed and Total count the documents or keys of that
+// phase. The server reports no progress for the short setup and commit
+// phases, during which Percent is 0.
+type IndexBuildProgress struct {
+	Done      bool    `js:"done"`
+	Phase     string  `js:"phase"`
+	Processed int64   `js:"processed"`
+	Total     int64   `js:"total"`
+	Percent   float64 `js:"percent"`
+}
+
+// StartCreateIndex starts creating an index in the background, with keys and
+// opts as for CreateIndex, and returns a handle to follow the build with
+// Progress and to wait for it with Wait. The build runs on the server like any
+// other, so the collection stays readable and writable. The index gets the
+// name the server would generate unless opts sets one. In dry-run mode the
+// handle reports a build that has already finished.
+func (c *Client) StartCreateIndex(database string, collection string, keys any, opts any) (*IndexBuild, error) {
+	model, err := indexModelFromMap(map[string]any{"keys": keys, "options": opts})
+	if err != nil {
+		return nil, err
+	}
+	if model.Options.Name == nil {
+		name, err := indexName(model.Keys)
+		if err != nil {
+			return nil, err
+		}
+		model.Options.SetName(name)
+	}
+
+	build := &IndexBuild{
+		client:     c,
+		database:   database,
+		collection: collection,
+		name:       *model.Options.Name,
+		done:       make(chan struct{}),
+	}
+	if c.dryRun("startCreateIndex", database, collection, "keys", model.Keys, "options", opts) {
+		// The handle reports a finished build.
+		close(build.done)
+		return build, nil
+	}
+	col := c.client.Database(database).Collection(collection)
+	// Not c.context(): inside WithTransaction or WithSnapshot that is a
+	// session context, which the script keeps using meanwhile.
+	ctx := context.Background()
+	go func() {
+		defer close(build.done)
+		if _, err := col.Indexes().CreateOne(ctx, model); err != nil {
+			log.Printf("Error while creating index %s: %v", build.name, err)
+			build.mu.Lock()
+			build.err = err
+			build.mu.Unlock()
+		}
+	}()
+	return build, nil
+}
+
+// Name returns the name of the index being built.
+func (b *IndexBuild) Name() string {
+	return b.name
+}
+
+// Progress reads the state of the build from the index build operations
+// that $currentOp reports. Once the build has finished it reports Done, or
+// returns the error the build failed with.
+func (b *IndexBuild) Progress() (*IndexBuildProgress, error) {
+	if finished, err := b.finished(); finished {
+		if err != nil {
+			return nil, err
+		}
+		return &IndexBuildProgress{Done: true, Percent: 100}, nil
+	}
+
+	ops, err := b.client.CurrentOp(bson.D{
+		{Key: "ns", Value: b.database + "." + b.collection},
+		{Key: "command.createIndexes", Value: b.collection},
+		{Key: "command.indexes.name", Value: b.name},
+	})
+	if err != nil {
+		return nil, err
+	}
+	progress := &IndexBuildProgress{}
+	for _, op := range ops {
+		if msg, ok := op["msg"].(string); ok && progress.Phase == "" {
+			progress.Phase = indexBuildPhase(msg)
+		}
+		p, ok := op["progress"].(bson.M)
+		if !ok {
+			continue
+		}
+		progress.Processed, _ = toInt64(p["done"])
+		progress.Total, _ = toInt64(p["total"])
+		if progress.Total > 0 {
+			progress.Percent = float64(progress.Processed) / float64(progress.Total) * 100
+		}
+		break
+	}
+	// The build may have finished while $currentOp was running.
+	if finished, err := b.finished(); finished {
+		if err != nil {
+			return nil, err
+		}
+		return &IndexBuildProgress{Done: true, Percent: 100}, nil
+	}
+	return progress, nil
+}
+
+// Wait blocks until the build has finished, for at most timeoutMs
+// milliseconds when positive, and returns the index name or the error the
+// build failed with. A timeout leaves the build running.
+func (b *IndexBuild) Wait(timeoutMs int64) (string, error) {
+	if timeoutMs > 0 {
+		select {
+		case <-b.done:
+		case <-time.After(time.Duration(timeoutMs) * time.Millisecond):
+			return "", fmt.Errorf("index %s is still building after %dms", b.name, timeoutMs)
+		}
+	} else {
+		<-b.done
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.err != nil {
+		return "", b.err
+	}
+	return b.name, nil
+}
+
+func (b *IndexBuild) finished() (bool, error) {
+	select {
+	case <-b.done:
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		return true, b.err
+	default:
+		return false, nil
+	}
+}
+
+// indexBuildCounters matches the counters the server appends to the message
+// of an index build operation.
+var indexBuildCounters = regexp.MustCompile(`:\s*\d+/\d+.*$`)
+
+// indexBuildPhase returns the phase of an index build operation from its
+// message, e.g. "Index Build: scanning collection Index Build: scanning
+// collection: 500/1000 50%", in which the server repeats the phase.
+func indexBuildPhase(msg string) string {
+	phase := strings.TrimSpace(indexBuildCounters.ReplaceAllString(msg, ""))
+	if half := len(phase) / 2; len(phase)%2 == 1 && phase[:half] == phase[half+1:] {
+		return phase[:half]
+	}
+	return phase
+}
+
+// indexName generates the name the server gives an index with the given
+// keys, e.g. sku_1_date_-1.
+func indexName(keys any) (string, error) {
+	raw, err := bson.Marshal(keys)
+	if err != nil {
+		return "", fmt.Errorf("invalid index keys: %w", err)
+	}
+	elems, err := bson.Raw(raw).Elements()
+	if err != nil {
+		return "", fmt.Errorf("invalid index keys: %w", err)
+	}
+	parts := make([]string, 0, 2*len(elems))
+	for _, elem := range elems {
+		value := elem.Value()
+		var s string
+		switch value.Type {
+		case bsontype.Int32:
+			s = fmt.Sprint(value.Int32())
+		case bsontype.Int64:
+			s = fmt.Sprint(value.Int64())
+		case bsontype.Double:
+			s = fmt.Sprint(value.Double())
+		case bsontype.String:
+			s = value.StringValue()
+		default:
+			return "", fmt.Errorf("invalid value %v for index key %s", value, elem.Key())
+		}
+		parts = append(parts, elem.Key(), s)
+	}
+	return strings.Join(parts, "_"), nil
+}
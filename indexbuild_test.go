@@ -0,0 +1,33 @@
+package xk6_mongo
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestIndexName(t *testing.T) {
+	name, err := indexName(bson.D{{Key: "sku", Value: int64(1)}, {Key: "date", Value: int32(-1)}, {Key: "loc", Value: "2dsphere"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if name != "sku_1_date_-1_loc_2dsphere" {
+		t.Fatalf("unexpected name %q", name)
+	}
+	if _, err := indexName(bson.D{{Key: "sku", Value: true}}); err == nil {
+		t.Fatalf("expected error for a boolean key")
+	}
+}
+
+func TestIndexBuildPhase(t *testing.T) {
+	tests := map[string]string{
+		"Index Build: scanning collection Index Build: scanning collection: 500/1000 50%": "Index Build: scanning collection",
+		"Index Build: inserting keys from external sorter into index: 10/20 50%":          "Index Build: inserting keys from external sorter into index",
+		"Index Build: draining writes received during build":                              "Index Build: draining writes received during build",
+	}
+	for msg, want := range tests {
+		if got := indexBuildPhase(msg); got != want {
+			t.Errorf("indexBuildPhase(%q) = %q, want %q", msg, got, want)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package xk6_mongo
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ingestTick is how often an ingest flushes the documents due at its rate.
+const ingestTick = 100 * time.Millisecond
+
+// Ingest is a handle on a background ingest started with StartIngest.
+type Ingest struct {
+	client     *Client
+	database   string
+	collection string
+	col        *mongo.Collection
+	rate       int
+	docs       chan any
+	stop       chan struct{}
+	done       chan struct{}
+	stopOnce   sync.Once
+	// submitMu keeps Stop from closing stop while a Submit is queuing a
+	// document, which would then miss the final flush.
+	submitMu sync.RWMutex
+
+	mu       sync.Mutex
+	inserted int64
+	err      error
+}
+
+// StartIngest starts inserting the documents submitted to the returned handle
+// into a collection at ratePerSec documents per second, in batches flushed
+// every 100ms. Submit blocks once a second's worth of documents is waiting,
+// so a script generating documents faster than the rate is paced by it. Stop
+// must be called to flush the remaining documents and end the ingest.
+func (c *Client) StartIngest(database string, collection string, ratePerSec int) (*Ingest, error) {
+	if ratePerSec <= 0 {
+		return nil, fmt.Errorf("rate must be positive, got %d", ratePerSec)
+	}
+	in := &Ingest{
+		client:     c,
+		database:   database,
+		collection: collection,
+		col:        c.client.Database(database).Collection(collection),
+		rate:       ratePerSec,
+		docs:       make(chan any, ratePerSec),
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+	go in.run()
+	return in, nil
+}
+
+// Submit queues doc for insertion. Documents over the client's size limit,
+// see SetMaxDocBytes, are rejected right away.
+func (in *Ingest) Submit(doc any) error {
+	if err := in.client.checkDocSize(doc); err != nil {
+		log.Printf("Error while submitting document: %v", err)
+		return err
+	}
+	in.submitMu.RLock()
+	defer in.submitMu.RUnlock()
+	select {
+	case <-in.stop:
+		return fmt.Errorf("ingest is stopped")
+	default:
+	}
+	// The run loop keeps draining docs until stop is closed, which cannot
+	// happen while the lock is held.
+	in.docs <- doc
+	return nil
+}
+
+// Stop inserts the documents still queued, ends the ingest and returns the
+// number of inserted documents along with the first insert error, if any.
+func (in *Ingest) Stop() (int64, error) {
+	in.stopOnce.Do(func() {
+		in.submitMu.Lock()
+		close(in.stop)
+		in.submitMu.Unlock()
+	})
+	<-in.done
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	return in.inserted, in.err
+}
+
+func (in *Ingest) run() {
+	defer close(in.done)
+	ticker := time.NewTicker(ingestTick)
+	defer ticker.Stop()
+
+	perTick := float64(in.rate) * ingestTick.Seconds()
+	var budget float64
+	for {
+		select {
+		case <-in.stop:
+			in.flush(len(in.docs))
+			return
+		case <-ticker.C:
+			// Unused budget does not carry over beyond one tick, so that an
+			// idle phase is not followed by a burst.
+			budget = min(budget+perTick, max(perTick, 1))
+			n := in.flush(int(budget))
+			budget -= float64(n)
+		}
+	}
+}
+
+// flush inserts up to n queued documents and returns how many it took.
+func (in *Ingest) flush(n int) int {
+	batch := make([]any, 0, n)
+drain:
+	for len(batch) < n {
+		select {
+		case doc := <-in.docs:
+			batch = append(batch, doc)
+		default:
+			break drain
+		}
+	}
+	if len(batch) == 0 {
+		return 0
+	}
+	if in.client.dryRun("ingest", in.database, in.collection, "documents", len(batch)) {
+		return len(batch)
+	}
+
+	ctx, cancel := in.client.operationContext()
+	defer cancel()
+
+	opts := options.InsertMany().SetOrdered(false).SetComment(in.client.commentOption())
+	res, err := withWriteRetry(ctx, in.client, func() (*mongo.InsertManyResult, error) { return in.col.InsertMany(ctx, batch, opts) })
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	in.inserted += unorderedInsertedCount(res, err)
+	err = classifyWriteError(err)
+	if err != nil {
+		log.Printf("Error while ingesting documents: %v", err)
+		if in.err == nil {
+			in.err = err
+		}
+	}
+	return len(batch)
+}
@@ -0,0 +1,57 @@
+package xk6_mongo
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// keepAlive pings the pool's minimum number of connections at a fixed
+// interval, so that they are in regular use during idle phases of a test and
+// are neither reaped by maxConnIdleTimeMS nor dropped by network idle
+// timeouts before the next ramp-up.
+type keepAlive struct {
+	interval time.Duration
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// startKeepAlive starts pinging the client's connections every interval until
+// stopKeepAlive is called.
+func (c *Client) startKeepAlive(interval time.Duration) {
+	connections := 1
+	if c.options.MinPoolSize != nil && *c.options.MinPoolSize > 1 {
+		connections = int(*c.options.MinPoolSize)
+	}
+
+	ka := &keepAlive{interval: interval, stop: make(chan struct{}), done: make(chan struct{})}
+	c.keepAlive = ka
+	go func() {
+		defer close(ka.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ka.stop:
+				return
+			case <-ticker.C:
+				ctx, cancel := context.WithTimeout(context.Background(), interval)
+				if err := c.pingConnections(ctx, connections); err != nil {
+					log.Printf("Error while keeping pooled connections alive: %v", err)
+				}
+				cancel()
+			}
+		}
+	}()
+}
+
+// stopKeepAlive stops the background pings, if any, and waits for a ping in
+// progress to finish.
+func (c *Client) stopKeepAlive() {
+	if c.keepAlive == nil {
+		return
+	}
+	close(c.keepAlive.stop)
+	<-c.keepAlive.done
+	c.keepAlive = nil
+}
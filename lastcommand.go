@@ -0,0 +1,41 @@
+package xk6_mongo
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// lastCommand records the duration of the most recently finished command of
+// a client. Its command monitor is installed on every client.
+type lastCommand struct {
+	duration atomic.Int64
+}
+
+func (l *lastCommand) commandMonitor() *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Succeeded: func(_ context.Context, evt *event.CommandSucceededEvent) {
+			l.duration.Store(int64(evt.Duration))
+		},
+		Failed: func(_ context.Context, evt *event.CommandFailedEvent) {
+			l.duration.Store(int64(evt.Duration))
+		},
+	}
+}
+
+// LastServerDuration returns, in milliseconds, the duration of the last wire
+// command the client finished, as reported by command monitoring, or 0 before
+// the first one. MongoDB does not return the execution time of a command in
+// its reply, so this is the time the driver measured from writing the command
+// to the connection to reading the reply: server time plus network transfer.
+// Subtracting it from the wall time of an operation leaves the client side
+// overhead, i.e. server selection, connection checkout, encoding and
+// decoding. An operation that sends several commands, such as a find reading
+// more than one batch, reports its last getMore. With operations running
+// concurrently, e.g. through async methods, the last command may belong to
+// another operation.
+func (c *Client) LastServerDuration() float64 {
+	return float64(time.Duration(c.lastCommand.duration.Load()).Microseconds()) / 1000
+}
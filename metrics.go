@@ -0,0 +1,112 @@
+package xk6_mongo
+
+import (
+	"context"
+	"time"
+
+	"go.k6.io/k6/metrics"
+	"go.mongodb.org/mongo-driver/event"
+
+	k6modules "go.k6.io/k6/js/modules"
+)
+
+// mongoMetrics holds the custom k6 metrics emitted by the extension.
+type mongoMetrics struct {
+	poolConnectionsCreated    *metrics.Metric
+	poolConnectionsCheckedOut *metrics.Metric
+	poolConnectionsCheckedIn  *metrics.Metric
+	poolCheckoutsFailed       *metrics.Metric
+	poolConnectionsClosed     *metrics.Metric
+	poolConnectionsOpen       *metrics.Metric
+	poolConnectionsInUse      *metrics.Metric
+	poolConnectionsIdle       *metrics.Metric
+	commandDuration           *metrics.Metric
+}
+
+func registerMetrics(vu k6modules.VU) *mongoMetrics {
+	registry := vu.InitEnv().Registry
+	return &mongoMetrics{
+		poolConnectionsCreated:    registry.MustNewMetric("mongo_pool_connections_created", metrics.Counter),
+		poolConnectionsCheckedOut: registry.MustNewMetric("mongo_pool_connections_checked_out", metrics.Counter),
+		poolConnectionsCheckedIn:  registry.MustNewMetric("mongo_pool_connections_checked_in", metrics.Counter),
+		poolCheckoutsFailed:       registry.MustNewMetric("mongo_pool_checkouts_failed", metrics.Counter),
+		poolConnectionsClosed:     registry.MustNewMetric("mongo_pool_connections_closed", metrics.Counter),
+		poolConnectionsOpen:       registry.MustNewMetric("mongo_pool_connections_open", metrics.Gauge),
+		poolConnectionsInUse:      registry.MustNewMetric("mongo_pool_connections_in_use", metrics.Gauge),
+		poolConnectionsIdle:       registry.MustNewMetric("mongo_pool_connections_idle", metrics.Gauge),
+		commandDuration:           registry.MustNewMetric("mongo_command_duration", metrics.Trend, metrics.Time),
+	}
+}
+
+// poolMonitor returns a pool monitor that counts connection pool events into
+// the pool metrics of the VU that created the client, and reports the pool
+// occupancy tracked by counters in the pool gauges whenever it changes.
+func (m *Mongo) poolMonitor(counters *poolCounters) *event.PoolMonitor {
+	return &event.PoolMonitor{
+		Event: func(evt *event.PoolEvent) {
+			var metric *metrics.Metric
+			switch evt.Type {
+			case event.ConnectionCreated:
+				metric = m.metrics.poolConnectionsCreated
+			case event.GetSucceeded:
+				metric = m.metrics.poolConnectionsCheckedOut
+			case event.ConnectionReturned:
+				metric = m.metrics.poolConnectionsCheckedIn
+			case event.GetFailed:
+				metric = m.metrics.poolCheckoutsFailed
+			case event.ConnectionClosed:
+				metric = m.metrics.poolConnectionsClosed
+			default:
+				return
+			}
+			m.pushSample(metric, 1, nil)
+			if metric == m.metrics.poolCheckoutsFailed {
+				return
+			}
+			stats := counters.stats()
+			m.pushSample(m.metrics.poolConnectionsOpen, float64(stats.Total), nil)
+			m.pushSample(m.metrics.poolConnectionsInUse, float64(stats.InUse), nil)
+			m.pushSample(m.metrics.poolConnectionsIdle, float64(stats.Idle), nil)
+		},
+	}
+}
+
+// commandMonitor returns a command monitor that records the duration of every
+// wire command, as measured by the driver from sending the command to
+// receiving its reply, tagged with the command name and outcome.
+func (m *Mongo) commandMonitor() *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Succeeded: func(_ context.Context, evt *event.CommandSucceededEvent) {
+			m.pushSample(m.metrics.commandDuration, metrics.D(evt.Duration),
+				map[string]string{"command": evt.CommandName, "status": "succeeded"})
+		},
+		Failed: func(_ context.Context, evt *event.CommandFailedEvent) {
+			m.pushSample(m.metrics.commandDuration, metrics.D(evt.Duration),
+				map[string]string{"command": evt.CommandName, "status": "failed"})
+		},
+	}
+}
+
+// pushSample emits a sample with the VU's current tags plus extraTags. Driver
+// monitors call this from their own goroutines, and samples are dropped while
+// the VU has no state, i.e. in the init context.
+func (m *Mongo) pushSample(metric *metrics.Metric, value float64, extraTags map[string]string) {
+	if m.vu == nil {
+		return
+	}
+	state := m.vu.State()
+	if state == nil {
+		return
+	}
+	ctm := state.Tags.GetCurrentValues()
+	tags := ctm.Tags
+	for key, val := range extraTags {
+		tags = tags.With(key, val)
+	}
+	metrics.PushIfNotDone(m.vu.Context(), state.Samples, metrics.Sample{
+		TimeSeries: metrics.TimeSeries{Metric: metric, Tags: tags},
+		Time:       time.Now(),
+		Value:      value,
+		Metadata:   ctm.Metadata,
+	})
+}
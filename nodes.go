@@ -0,0 +1,227 @@
+package xk6_mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/description"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// replicationPollInterval is how often WaitForReplication checks each
+// secondary for the document.
+const replicationPollInterval = 5 * time.Millisecond
+
+// nodeClients are direct connections to single members of the deployment,
+// keyed by address, created on first use and closed on Disconnect.
+type nodeClients struct {
+	mu      sync.Mutex
+	clients map[string]*mongo.Client
+}
+
+// nodeClient returns a client connected directly to the member at host,
+// reusing the credentials, TLS settings and timeouts of c, so that reads
+// through it are served by that member whatever its state.
+func (c *Client) nodeClient(host string) (*mongo.Client, error) {
+	c.nodes.mu.Lock()
+	defer c.nodes.mu.Unlock()
+
+	if client, ok := c.nodes.clients[host]; ok {
+		return client, nil
+	}
+	client, err := mongo.Connect(context.Background(), directNodeOptions(c.options, host))
+	if err != nil {
+		return nil, fmt.Errorf("connecting to %s: %w", host, err)
+	}
+	if c.nodes.clients == nil {
+		c.nodes.clients = map[string]*mongo.Client{}
+	}
+	c.nodes.clients[host] = client
+	return client, nil
+}
+
+func (c *Client) closeNodeClients() {
+	if c.nodes == nil {
+		return
+	}
+	c.nodes.mu.Lock()
+	defer c.nodes.mu.Unlock()
+
+	for host, client := range c.nodes.clients {
+		if err := client.Disconnect(context.Background()); err != nil {
+			log.Printf("Error while disconnecting from %s: %v", host, err)
+		}
+	}
+	c.nodes.clients = nil
+}
+
+// directNodeOptions builds the options of a direct connection to host from
+// base. They are built anew rather than copied since base may come from an
+// SRV URI, which rules out direct connections, and must not share the
+// client's monitors.
+func directNodeOptions(base *options.ClientOptions, host string) *options.ClientOptions {
+	opts := options.Client().SetHosts([]string{host}).SetDirect(true).SetMaxPoolSize(1)
+	if base.Auth != nil {
+		opts.SetAuth(*base.Auth)
+	}
+	if base.TLSConfig != nil {
+		opts.SetTLSConfig(base.TLSConfig)
+	}
+	if base.AppName != nil {
+		opts.SetAppName(*base.AppName)
+	}
+	if base.ConnectTimeout != nil {
+		opts.SetConnectTimeout(*base.ConnectTimeout)
+	}
+	if base.ServerSelectionTimeout != nil {
+		opts.SetServerSelectionTimeout(*base.ServerSelectionTimeout)
+	}
+	if base.Dialer != nil {
+		opts.SetDialer(base.Dialer)
+	}
+	if base.ServerAPIOptions != nil {
+		opts.SetServerAPIOptions(base.ServerAPIOptions)
+	}
+	return opts
+}
+
+// secondaries returns the addresses of the secondaries the client currently
+// knows of.
+func (c *Client) secondaries() []string {
+	var hosts []string
+	for _, server := range c.topology.servers() {
+		if server.Kind == description.RSSecondary {
+			hosts = append(hosts, server.Addr.String())
+		}
+	}
+	return hosts
+}
+
+// WaitForReplication polls the secondaries directly until a document matching
+// filter, typically the one just written, is visible on nodes of them, and
+// returns the elapsed time in milliseconds, i.e. the replication latency of
+// the write when called right after it. It fails if fewer secondaries are
+// known or if they do not all see the document within timeoutMs.
+func (c *Client) WaitForReplication(database string, collection string, filter any, nodes int, timeoutMs int64) (float64, error) {
+	filter = c.coerceFilter(filter)
+	start := time.Now()
+	if nodes <= 0 {
+		return 0, fmt.Errorf("nodes must be positive, got %d", nodes)
+	}
+	if timeoutMs <= 0 {
+		return 0, fmt.Errorf("timeout must be positive, got %d", timeoutMs)
+	}
+	hosts := c.secondaries()
+	if len(hosts) < nodes {
+		return 0, fmt.Errorf("cannot wait for %d secondaries, the client knows of %d", nodes, len(hosts))
+	}
+
+	ctx, cancel := context.WithTimeout(c.context(), time.Duration(timeoutMs)*time.Millisecond)
+	defer cancel()
+
+	seen := make(chan error, len(hosts))
+	for _, host := range hosts {
+		go func(host string) {
+			seen <- c.pollNode(ctx, host, database, collection, filter)
+		}(host)
+	}
+
+	visible := 0
+	for range hosts {
+		if err := <-seen; err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			log.Printf("Error while polling secondary: %v", err)
+			continue
+		}
+		visible++
+		if visible == nodes {
+			return float64(time.Since(start).Microseconds()) / 1000, nil
+		}
+	}
+	return 0, fmt.Errorf("document visible on %d of %d secondaries after %dms", visible, nodes, time.Since(start).Milliseconds())
+}
+
+// pollNode returns once the member at host returns a document matching
+// filter, or with an error when ctx is done first.
+func (c *Client) pollNode(ctx context.Context, host string, database string, collection string, filter any) error {
+	client, err := c.nodeClient(host)
+	if err != nil {
+		return err
+	}
+	col := client.Database(database).Collection(collection)
+	opts := options.FindOne().SetProjection(bson.D{{Key: "_id", Value: 1}})
+	for {
+		err := col.FindOne(ctx, filter, opts).Err()
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, mongo.ErrNoDocuments) {
+			return fmt.Errorf("reading from %s: %w", host, err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(replicationPollInterval):
+		}
+	}
+}
+
+// NodeLatencies pings every server the client knows of over a direct
+// connection and returns the round trip times in milliseconds, keyed by
+// address. Each server is pinged once beforehand so that connection setup is
+// not measured. Servers that cannot be reached are logged and left out.
+func (c *Client) NodeLatencies() (map[string]float64, error) {
+	ctx, cancel := c.operationContext()
+	defer cancel()
+
+	servers := c.topology.servers()
+	if len(servers) == 0 {
+		// Wait for the initial discovery.
+		if err := c.client.Ping(ctx, nil); err != nil {
+			log.Printf("Error while discovering servers: %v", err)
+			return nil, err
+		}
+		servers = c.topology.servers()
+	}
+
+	type latency struct {
+		host string
+		rtt  time.Duration
+		err  error
+	}
+	results := make(chan latency, len(servers))
+	for _, server := range servers {
+		go func(host string) {
+			client, err := c.nodeClient(host)
+			if err == nil {
+				err = client.Ping(ctx, nil)
+			}
+			if err != nil {
+				results <- latency{host: host, err: err}
+				return
+			}
+			start := time.Now()
+			err = client.Ping(ctx, nil)
+			results <- latency{host: host, rtt: time.Since(start), err: err}
+		}(server.Addr.String())
+	}
+
+	latencies := make(map[string]float64, len(servers))
+	for range servers {
+		res := <-results
+		if res.err != nil {
+			log.Printf("Error while pinging %s: %v", res.host, res.err)
+			continue
+		}
+		latencies[res.host] = float64(res.rtt.Microseconds()) / 1000
+	}
+	return latencies, nil
+}
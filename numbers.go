@@ -0,0 +1,136 @@
+package xk6_mongo
+
+import (
+	"fmt"
+	"strconv"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// LongFilter returns the filter { field: value } with value as a 64-bit
+// integer, so that it matches Int64 fields exactly instead of being sent as
+// a double. Values beyond 2^53 can be given as decimal strings, since JS
+// numbers cannot represent them.
+func (*Mongo) LongFilter(field string, value any) (bson.M, error) {
+	long, err := toLong(value)
+	if err != nil {
+		return nil, err
+	}
+	return bson.M{field: long}, nil
+}
+
+// DecimalFilter returns the filter { field: value } with value as a
+// Decimal128, for exact matches on decimal fields. The value should be given
+// as a string, since a JS number has already lost the digits a double cannot
+// hold.
+func (*Mongo) DecimalFilter(field string, value any) (bson.M, error) {
+	dec, err := toDecimal(value)
+	if err != nil {
+		return nil, err
+	}
+	return bson.M{field: dec}, nil
+}
+
+// SetCoerceNumbers makes every method taking a filter convert the canonical
+// Extended JSON wrappers { $numberLong: "..." }, { $numberInt: "..." } and
+// { $numberDecimal: "..." } to Int64, Int32 and Decimal128 values, wherever
+// they occur in the filter. Without it they are sent as embedded documents
+// and match nothing. The caller's filter is left unchanged.
+func (c *Client) SetCoerceNumbers(enabled bool) {
+	c.coerceNumbers = enabled
+}
+
+func toLong(value any) (int64, error) {
+	if s, ok := value.(string); ok {
+		long, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid long %q", s)
+		}
+		return long, nil
+	}
+	return toInt64(value)
+}
+
+func toDecimal(value any) (primitive.Decimal128, error) {
+	var s string
+	switch v := value.(type) {
+	case string:
+		s = v
+	case int64:
+		s = strconv.FormatInt(v, 10)
+	case float64:
+		s = strconv.FormatFloat(v, 'g', -1, 64)
+	default:
+		return primitive.Decimal128{}, fmt.Errorf("expected a decimal string, got %T", value)
+	}
+	dec, err := primitive.ParseDecimal128(s)
+	if err != nil {
+		return primitive.Decimal128{}, fmt.Errorf("invalid decimal %q", s)
+	}
+	return dec, nil
+}
+
+// coerceNumberWrappers replaces the numeric Extended JSON wrappers in value,
+// see SetCoerceNumbers.
+func coerceNumberWrappers(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		if number, ok := numberWrapper(v); ok {
+			return number
+		}
+		out := make(map[string]any, len(v))
+		for key, elem := range v {
+			out[key] = coerceNumberWrappers(elem)
+		}
+		return out
+	case bson.M:
+		if number, ok := numberWrapper(v); ok {
+			return number
+		}
+		out := make(bson.M, len(v))
+		for key, elem := range v {
+			out[key] = coerceNumberWrappers(elem)
+		}
+		return out
+	case bson.D:
+		out := make(bson.D, len(v))
+		for i, elem := range v {
+			out[i] = bson.E{Key: elem.Key, Value: coerceNumberWrappers(elem.Value)}
+		}
+		return out
+	case []any, bson.A:
+		return coerceArray(v, coerceNumberWrappers)
+	default:
+		return value
+	}
+}
+
+// numberWrapper returns the number held by a single key wrapper document. A
+// wrapper whose string does not parse is left as is.
+func numberWrapper(doc map[string]any) (any, bool) {
+	if len(doc) != 1 {
+		return nil, false
+	}
+	for key, value := range doc {
+		s, ok := value.(string)
+		if !ok {
+			return nil, false
+		}
+		switch key {
+		case "$numberLong":
+			if long, err := strconv.ParseInt(s, 10, 64); err == nil {
+				return long, true
+			}
+		case "$numberInt":
+			if i, err := strconv.ParseInt(s, 10, 32); err == nil {
+				return int32(i), true
+			}
+		case "$numberDecimal":
+			if dec, err := primitive.ParseDecimal128(s); err == nil {
+				return dec, true
+			}
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,882 @@
+package xk6_mongo
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+	"go.mongodb.org/mongo-driver/tag"
+)
+
+// clientSettings are the extension's own settings, accepted alongside the
+// driver options when creating a client.
+type clientSettings struct {
+	// poolMetrics emits k6 metrics for connection pool events.
+	poolMetrics bool
+	// commandMetrics records the duration of every wire command in a trend.
+	commandMetrics bool
+	// keepAliveInterval, when non-zero, is how often the pool's minimum
+	// connections are pinged in the background.
+	keepAliveInterval time.Duration
+	// kmsProviders are the KMS provider credentials used for client-side
+	// field level encryption, keyed by provider name.
+	kmsProviders map[string]map[string]any
+	// keyVaultNamespace is the default key vault collection of the
+	// encryption methods, as "database.collection".
+	keyVaultNamespace string
+}
+
+// splitClientSettings removes the extension settings from a client options
+// map and returns them together with the remaining driver options.
+func splitClientSettings(opts any) (clientSettings, any, error) {
+	var settings clientSettings
+	var raw map[string]any
+	switch v := opts.(type) {
+	case map[string]any:
+		raw = v
+	case bson.M:
+		raw = map[string]any(v)
+	default:
+		return settings, opts, nil
+	}
+
+	rest := make(map[string]any, len(raw))
+	for key, value := range raw {
+		switch key {
+		case "poolMetrics":
+			enabled, ok := value.(bool)
+			if !ok {
+				return settings, nil, fmt.Errorf("poolMetrics must be a boolean")
+			}
+			settings.poolMetrics = enabled
+		case "commandMetrics":
+			enabled, ok := value.(bool)
+			if !ok {
+				return settings, nil, fmt.Errorf("commandMetrics must be a boolean")
+			}
+			settings.commandMetrics = enabled
+		case "keepAliveIntervalMS":
+			interval, err := toDurationMS(value)
+			if err != nil {
+				return settings, nil, fmt.Errorf("invalid keepAliveIntervalMS: %w", err)
+			}
+			settings.keepAliveInterval = interval
+		case "kmsProviders":
+			providers, err := parseKMSProviders(value)
+			if err != nil {
+				return settings, nil, fmt.Errorf("invalid kmsProviders: %w", err)
+			}
+			settings.kmsProviders = providers
+		case "keyVaultNamespace":
+			namespace, ok := value.(string)
+			if !ok {
+				return settings, nil, fmt.Errorf("keyVaultNamespace must be a string")
+			}
+			settings.keyVaultNamespace = namespace
+		default:
+			rest[key] = value
+		}
+	}
+	return settings, rest, nil
+}
+
+// parseKMSProviders accepts { provider: { credential: value, ... }, ... },
+// e.g. { local: { key: "<96 bytes, base64 encoded>" } }.
+func parseKMSProviders(value any) (map[string]map[string]any, error) {
+	raw, err := optionsMap(value)
+	if err != nil {
+		return nil, err
+	}
+	providers := make(map[string]map[string]any, len(raw))
+	for name, credentials := range raw {
+		provider, err := optionsMap(credentials)
+		if err != nil {
+			return nil, fmt.Errorf("provider %s: %w", name, err)
+		}
+		providers[name] = provider
+	}
+	return providers, nil
+}
+
+func prepareTransactionOptions(opts any) (*options.TransactionOptions, error) {
+	raw, err := optionsMap(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	txnOptions := options.Transaction()
+	for key, value := range raw {
+		switch key {
+		case "readConcern":
+			rc, err := parseReadConcern(value)
+			if err != nil {
+				return nil, err
+			}
+			txnOptions.SetReadConcern(rc)
+		case "writeConcern":
+			wc, err := parseWriteConcern(value)
+			if err != nil {
+				return nil, err
+			}
+			txnOptions.SetWriteConcern(wc)
+		case "readPreference":
+			rp, err := parseReadPreference(value)
+			if err != nil {
+				return nil, err
+			}
+			txnOptions.SetReadPreference(rp)
+		case "maxCommitTimeMS":
+			ms, err := toInt64(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid maxCommitTimeMS: %w", err)
+			}
+			d := time.Duration(ms) * time.Millisecond
+			txnOptions.SetMaxCommitTime(&d)
+		default:
+			return nil, fmt.Errorf("unsupported transaction option %q", key)
+		}
+	}
+	return txnOptions, nil
+}
+
+// optionsMap accepts the shapes an options argument can arrive in from JS and
+// returns it as a plain map. A missing argument yields an empty map.
+func optionsMap(opts any) (map[string]any, error) {
+	switch v := opts.(type) {
+	case nil:
+		return map[string]any{}, nil
+	case map[string]any:
+		return v, nil
+	case bson.M:
+		return map[string]any(v), nil
+	default:
+		return nil, fmt.Errorf("unsupported options type %T", opts)
+	}
+}
+
+// parseReadConcern accepts a level ("local", "majority", "snapshot", ...) or
+// a document of the form { level: "..." }.
+func parseReadConcern(value any) (*readconcern.ReadConcern, error) {
+	switch v := value.(type) {
+	case string:
+		return &readconcern.ReadConcern{Level: v}, nil
+	case map[string]any:
+		level, ok := v["level"].(string)
+		if !ok {
+			return nil, fmt.Errorf("read concern document requires a string level")
+		}
+		return &readconcern.ReadConcern{Level: level}, nil
+	default:
+		return nil, fmt.Errorf("unsupported read concern type %T", value)
+	}
+}
+
+// parseWriteConcern accepts "majority", a tag set name, a node count, or a
+// document of the form { w, j, wtimeoutMS }.
+func parseWriteConcern(value any) (*writeconcern.WriteConcern, error) {
+	switch v := value.(type) {
+	case string:
+		return &writeconcern.WriteConcern{W: v}, nil
+	case map[string]any:
+		wc := &writeconcern.WriteConcern{}
+		for key, val := range v {
+			switch key {
+			case "w":
+				if s, ok := val.(string); ok {
+					wc.W = s
+					continue
+				}
+				n, err := toInt64(val)
+				if err != nil {
+					return nil, fmt.Errorf("invalid write concern w: %w", err)
+				}
+				wc.W = int(n)
+			case "j":
+				j, ok := val.(bool)
+				if !ok {
+					return nil, fmt.Errorf("write concern j must be a boolean")
+				}
+				wc.Journal = &j
+			case "wtimeoutMS", "wtimeout":
+				ms, err := toInt64(val)
+				if err != nil {
+					return nil, fmt.Errorf("invalid write concern wtimeout: %w", err)
+				}
+				wc.WTimeout = time.Duration(ms) * time.Millisecond
+			default:
+				return nil, fmt.Errorf("unsupported write concern option %q", key)
+			}
+		}
+		return wc, nil
+	default:
+		n, err := toInt64(value)
+		if err != nil {
+			return nil, fmt.Errorf("unsupported write concern type %T", value)
+		}
+		return &writeconcern.WriteConcern{W: int(n)}, nil
+	}
+}
+
+// parseReadPreference accepts a mode name such as "secondaryPreferred" or a
+// document of the form { mode: "..." }.
+func parseReadPreference(value any) (*readpref.ReadPref, error) {
+	switch v := value.(type) {
+	case string:
+		mode, err := readpref.ModeFromString(v)
+		if err != nil {
+			return nil, err
+		}
+		return readpref.New(mode)
+	case map[string]any:
+		name, ok := v["mode"].(string)
+		if !ok {
+			return nil, fmt.Errorf("read preference document requires a string mode")
+		}
+		mode, err := readpref.ModeFromString(name)
+		if err != nil {
+			return nil, err
+		}
+		tags, ok := v["tags"]
+		if !ok {
+			return readpref.New(mode)
+		}
+		tagSets, err := parseTagSets(tags)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tags: %w", err)
+		}
+		return readpref.New(mode, readpref.WithTagSets(tagSets...))
+	default:
+		return nil, fmt.Errorf("unsupported read preference type %T", value)
+	}
+}
+
+// parseTagSets accepts a single tag set such as { region: "us-east" } or a
+// list of tag sets tried in order, in which {} matches any server.
+func parseTagSets(value any) ([]tag.Set, error) {
+	var list []any
+	switch v := value.(type) {
+	case []any:
+		list = v
+	default:
+		list = []any{v}
+	}
+	sets := make([]tag.Set, len(list))
+	for i, item := range list {
+		raw, err := optionsMap(item)
+		if err != nil {
+			return nil, err
+		}
+		tags := make(map[string]string, len(raw))
+		for name, tagValue := range raw {
+			s, ok := tagValue.(string)
+			if !ok {
+				return nil, fmt.Errorf("tag %q must be a string", name)
+			}
+			tags[name] = s
+		}
+		sets[i] = tag.NewTagSetFromMap(tags)
+	}
+	return sets, nil
+}
+
+// toInt64 converts the numeric types a JS number can be exported as.
+func toInt64(value any) (int64, error) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("expected an integer, got %v", v)
+		}
+		return int64(v), nil
+	default:
+		return 0, fmt.Errorf("expected a number, got %T", value)
+	}
+}
+
+// indexModelFromMap builds an index model from { keys, options }. Because JS
+// objects lose their key order on the way into Go, compound keys can also be
+// given as an array of [field, direction] pairs.
+func indexModelFromMap(raw map[string]any) (mongo.IndexModel, error) {
+	keysValue, ok := raw["keys"]
+	if !ok {
+		return mongo.IndexModel{}, fmt.Errorf("index model requires keys")
+	}
+	keys, err := orderedKeys(keysValue)
+	if err != nil {
+		return mongo.IndexModel{}, err
+	}
+
+	rawOpts, err := optionsMap(raw["options"])
+	if err != nil {
+		return mongo.IndexModel{}, err
+	}
+	indexOptions, err := prepareIndexOptions(rawOpts)
+	if err != nil {
+		return mongo.IndexModel{}, err
+	}
+
+	return mongo.IndexModel{Keys: keys, Options: indexOptions}, nil
+}
+
+func prepareIndexOptions(raw map[string]any) (*options.IndexOptions, error) {
+	indexOptions := options.Index()
+	for key, value := range raw {
+		switch key {
+		case "name":
+			name, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("index name must be a string")
+			}
+			indexOptions.SetName(name)
+		case "unique":
+			unique, ok := value.(bool)
+			if !ok {
+				return nil, fmt.Errorf("index unique must be a boolean")
+			}
+			indexOptions.SetUnique(unique)
+		case "sparse":
+			sparse, ok := value.(bool)
+			if !ok {
+				return nil, fmt.Errorf("index sparse must be a boolean")
+			}
+			indexOptions.SetSparse(sparse)
+		case "hidden":
+			hidden, ok := value.(bool)
+			if !ok {
+				return nil, fmt.Errorf("index hidden must be a boolean")
+			}
+			indexOptions.SetHidden(hidden)
+		case "expireAfterSeconds":
+			seconds, err := toInt64(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid expireAfterSeconds: %w", err)
+			}
+			indexOptions.SetExpireAfterSeconds(int32(seconds))
+		case "partialFilterExpression":
+			indexOptions.SetPartialFilterExpression(value)
+		case "collation":
+			collation, err := parseCollation(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid collation: %w", err)
+			}
+			indexOptions.SetCollation(collation)
+		default:
+			return nil, fmt.Errorf("unsupported index option %q", key)
+		}
+	}
+	return indexOptions, nil
+}
+
+// parseCollation accepts a collation document such as
+// { locale: "en", strength: 2 }, which compares strings case-insensitively.
+func parseCollation(value any) (*options.Collation, error) {
+	raw, err := optionsMap(value)
+	if err != nil {
+		return nil, err
+	}
+	collation := &options.Collation{}
+	for key, v := range raw {
+		var ok bool
+		switch key {
+		case "locale":
+			collation.Locale, ok = v.(string)
+		case "caseLevel":
+			collation.CaseLevel, ok = v.(bool)
+		case "caseFirst":
+			collation.CaseFirst, ok = v.(string)
+		case "strength":
+			var n int64
+			n, err = toInt64(v)
+			collation.Strength, ok = int(n), err == nil
+		case "numericOrdering":
+			collation.NumericOrdering, ok = v.(bool)
+		case "alternate":
+			collation.Alternate, ok = v.(string)
+		case "maxVariable":
+			collation.MaxVariable, ok = v.(string)
+		case "normalization":
+			collation.Normalization, ok = v.(bool)
+		case "backwards":
+			collation.Backwards, ok = v.(bool)
+		default:
+			return nil, fmt.Errorf("unsupported collation field %q", key)
+		}
+		if !ok {
+			return nil, fmt.Errorf("collation field %s has an invalid value %v", key, v)
+		}
+	}
+	if collation.Locale == "" {
+		return nil, fmt.Errorf("collation requires a locale")
+	}
+	return collation, nil
+}
+
+func prepareCreateCollectionOptions(opts any) (*options.CreateCollectionOptions, error) {
+	raw, err := optionsMap(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	collOptions := options.CreateCollection()
+	for key, value := range raw {
+		switch key {
+		case "timeseries":
+			var ts *options.TimeSeriesOptions
+			if ts, err = parseTimeSeriesOptions(value); err == nil {
+				collOptions.SetTimeSeriesOptions(ts)
+			}
+		case "expireAfterSeconds":
+			var seconds int64
+			if seconds, err = toInt64(value); err == nil {
+				collOptions.SetExpireAfterSeconds(seconds)
+			}
+		case "collation":
+			var collation *options.Collation
+			if collation, err = parseCollation(value); err == nil {
+				collOptions.SetCollation(collation)
+			}
+		case "validator":
+			collOptions.SetValidator(value)
+		case "capped":
+			capped, ok := value.(bool)
+			if !ok {
+				return nil, fmt.Errorf("capped must be a boolean")
+			}
+			collOptions.SetCapped(capped)
+		case "size":
+			var size int64
+			if size, err = toInt64(value); err == nil {
+				collOptions.SetSizeInBytes(size)
+			}
+		case "max":
+			var maxDocs int64
+			if maxDocs, err = toInt64(value); err == nil {
+				collOptions.SetMaxDocuments(maxDocs)
+			}
+		default:
+			return nil, fmt.Errorf("unsupported collection option %q", key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", key, err)
+		}
+	}
+	return collOptions, nil
+}
+
+// parseTimeSeriesOptions accepts { timeField, metaField, granularity,
+// bucketMaxSpanSeconds, bucketRoundingSeconds }, of which timeField is
+// required.
+func parseTimeSeriesOptions(value any) (*options.TimeSeriesOptions, error) {
+	raw, err := optionsMap(value)
+	if err != nil {
+		return nil, err
+	}
+	ts := options.TimeSeries()
+	for key, v := range raw {
+		switch key {
+		case "timeField", "metaField", "granularity":
+			field, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s must be a string", key)
+			}
+			switch key {
+			case "timeField":
+				ts.SetTimeField(field)
+			case "metaField":
+				ts.SetMetaField(field)
+			default:
+				ts.SetGranularity(field)
+			}
+		case "bucketMaxSpanSeconds", "bucketRoundingSeconds":
+			seconds, err := toInt64(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s: %w", key, err)
+			}
+			if key == "bucketMaxSpanSeconds" {
+				ts.SetBucketMaxSpan(time.Duration(seconds) * time.Second)
+			} else {
+				ts.SetBucketRounding(time.Duration(seconds) * time.Second)
+			}
+		default:
+			return nil, fmt.Errorf("unsupported time series option %q", key)
+		}
+	}
+	if ts.TimeField == "" {
+		return nil, fmt.Errorf("time series collections require a timeField")
+	}
+	return ts, nil
+}
+
+// orderedKeys returns a key specification as a bson.D when it was given as an
+// array of [field, value] pairs, and unchanged otherwise.
+func orderedKeys(value any) (any, error) {
+	pairs, ok := value.([]any)
+	if !ok {
+		return value, nil
+	}
+	keys := make(bson.D, 0, len(pairs))
+	for i, p := range pairs {
+		pair, ok := p.([]any)
+		if !ok || len(pair) != 2 {
+			return nil, fmt.Errorf("key %d must be a [field, value] pair", i)
+		}
+		field, ok := pair[0].(string)
+		if !ok {
+			return nil, fmt.Errorf("key %d field must be a string", i)
+		}
+		keys = append(keys, bson.E{Key: field, Value: pair[1]})
+	}
+	return keys, nil
+}
+
+// applyClientConfig applies the curated, camelCase client settings found in
+// raw to clientOptions and returns the keys it did not recognize, which are
+// left to the generic options mapping.
+func applyClientConfig(clientOptions *options.ClientOptions, raw map[string]any) (map[string]any, error) {
+	remaining := make(map[string]any, len(raw))
+	for key, value := range raw {
+		var err error
+		switch key {
+		case "appName":
+			name, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("appName must be a string")
+			}
+			clientOptions.SetAppName(name)
+		case "replicaSet":
+			name, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("replicaSet must be a string")
+			}
+			clientOptions.SetReplicaSet(name)
+		case "maxPoolSize":
+			var n int64
+			if n, err = toInt64(value); err == nil {
+				clientOptions.SetMaxPoolSize(uint64(n))
+			}
+		case "minPoolSize":
+			var n int64
+			if n, err = toInt64(value); err == nil {
+				clientOptions.SetMinPoolSize(uint64(n))
+			}
+		case "maxConnecting":
+			var n int64
+			if n, err = toInt64(value); err == nil {
+				clientOptions.SetMaxConnecting(uint64(n))
+			}
+		case "heartbeatFrequencyMS":
+			var d time.Duration
+			if d, err = toDurationMS(value); err == nil {
+				clientOptions.SetHeartbeatInterval(d)
+			}
+		case "maxConnIdleTimeMS":
+			var d time.Duration
+			if d, err = toDurationMS(value); err == nil {
+				clientOptions.SetMaxConnIdleTime(d)
+			}
+		case "connectTimeoutMS":
+			var d time.Duration
+			if d, err = toDurationMS(value); err == nil {
+				clientOptions.SetConnectTimeout(d)
+			}
+		case "serverSelectionTimeoutMS":
+			var d time.Duration
+			if d, err = toDurationMS(value); err == nil {
+				clientOptions.SetServerSelectionTimeout(d)
+			}
+		case "socketTimeoutMS":
+			var d time.Duration
+			if d, err = toDurationMS(value); err == nil {
+				clientOptions.SetSocketTimeout(d)
+			}
+		case "timeoutMS":
+			var d time.Duration
+			if d, err = toDurationMS(value); err == nil {
+				clientOptions.SetTimeout(d)
+			}
+		case "readPreference":
+			var rp *readpref.ReadPref
+			if rp, err = parseReadPreference(value); err == nil {
+				clientOptions.SetReadPreference(rp)
+			}
+		case "readConcern":
+			var rc *readconcern.ReadConcern
+			if rc, err = parseReadConcern(value); err == nil {
+				clientOptions.SetReadConcern(rc)
+			}
+		case "writeConcern":
+			var wc *writeconcern.WriteConcern
+			if wc, err = parseWriteConcern(value); err == nil {
+				clientOptions.SetWriteConcern(wc)
+			}
+		case "compressors":
+			var compressors []string
+			if compressors, err = toStrings(value); err == nil {
+				clientOptions.SetCompressors(compressors)
+			}
+		case "retryWrites":
+			retry, ok := value.(bool)
+			if !ok {
+				return nil, fmt.Errorf("retryWrites must be a boolean")
+			}
+			clientOptions.SetRetryWrites(retry)
+		case "retryReads":
+			retry, ok := value.(bool)
+			if !ok {
+				return nil, fmt.Errorf("retryReads must be a boolean")
+			}
+			clientOptions.SetRetryReads(retry)
+		default:
+			remaining[key] = value
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", key, err)
+		}
+	}
+	return remaining, nil
+}
+
+func toDurationMS(value any) (time.Duration, error) {
+	ms, err := toInt64(value)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(ms) * time.Millisecond, nil
+}
+
+func toStrings(value any) ([]string, error) {
+	switch v := value.(type) {
+	case []string:
+		return v, nil
+	case []any:
+		out := make([]string, 0, len(v))
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("expected a string, got %T", item)
+			}
+			out = append(out, s)
+		}
+		return out, nil
+	case string:
+		return []string{v}, nil
+	default:
+		return nil, fmt.Errorf("expected a list of strings, got %T", value)
+	}
+}
+
+// prepareFindReadOptions is prepareFindOptions that also accepts a
+// readPreference for the single find, returned separately since the driver
+// applies it to the collection.
+func prepareFindReadOptions(opts any) (*options.FindOptions, *readpref.ReadPref, error) {
+	raw, err := optionsMap(opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	value, ok := raw["readPreference"]
+	if !ok {
+		findOptions, err := prepareFindOptions(raw)
+		return findOptions, nil, err
+	}
+	readPref, err := parseReadPreference(value)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid readPreference: %w", err)
+	}
+	rest := make(map[string]any, len(raw)-1)
+	for key, v := range raw {
+		if key != "readPreference" {
+			rest[key] = v
+		}
+	}
+	findOptions, err := prepareFindOptions(rest)
+	return findOptions, readPref, err
+}
+
+func prepareFindOptions(opts any) (*options.FindOptions, error) {
+	raw, err := optionsMap(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	findOptions := options.Find()
+	for key, value := range raw {
+		switch key {
+		case "sort":
+			var sort any
+			if sort, err = orderedKeys(value); err == nil {
+				findOptions.SetSort(sort)
+			}
+		case "projection":
+			findOptions.SetProjection(value)
+		case "hint":
+			var hint any
+			if hint, err = parseHint(value); err == nil {
+				findOptions.SetHint(hint)
+			}
+		case "limit":
+			var n int64
+			if n, err = toInt64(value); err == nil {
+				findOptions.SetLimit(n)
+			}
+		case "skip":
+			var n int64
+			if n, err = toInt64(value); err == nil {
+				findOptions.SetSkip(n)
+			}
+		case "batchSize":
+			var n int64
+			if n, err = toInt64(value); err == nil {
+				findOptions.SetBatchSize(int32(n))
+			}
+		case "maxTimeMS":
+			var d time.Duration
+			if d, err = toDurationMS(value); err == nil {
+				findOptions.SetMaxTime(d)
+			}
+		case "noCursorTimeout":
+			enabled, ok := value.(bool)
+			if !ok {
+				return nil, fmt.Errorf("noCursorTimeout must be a boolean")
+			}
+			findOptions.SetNoCursorTimeout(enabled)
+		case "allowPartialResults":
+			allow, ok := value.(bool)
+			if !ok {
+				return nil, fmt.Errorf("allowPartialResults must be a boolean")
+			}
+			findOptions.SetAllowPartialResults(allow)
+		case "comment":
+			comment, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("comment must be a string")
+			}
+			findOptions.SetComment(comment)
+		default:
+			return nil, fmt.Errorf("unsupported find option %q", key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", key, err)
+		}
+	}
+	return findOptions, nil
+}
+
+// prepareAggregateOptions accepts hint, allowDiskUse, batchSize, maxTimeMS,
+// collation and comment. The hint can be an index key document or an index
+// name.
+func prepareAggregateOptions(opts any) (*options.AggregateOptions, error) {
+	raw, err := optionsMap(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	aggOptions := options.Aggregate()
+	for key, value := range raw {
+		switch key {
+		case "hint":
+			var hint any
+			if hint, err = parseHint(value); err == nil {
+				aggOptions.SetHint(hint)
+			}
+		case "allowDiskUse":
+			allow, ok := value.(bool)
+			if !ok {
+				return nil, fmt.Errorf("allowDiskUse must be a boolean")
+			}
+			aggOptions.SetAllowDiskUse(allow)
+		case "batchSize":
+			var n int64
+			if n, err = toInt64(value); err == nil {
+				aggOptions.SetBatchSize(int32(n))
+			}
+		case "maxTimeMS":
+			var d time.Duration
+			if d, err = toDurationMS(value); err == nil {
+				aggOptions.SetMaxTime(d)
+			}
+		case "collation":
+			var collation *options.Collation
+			if collation, err = parseCollation(value); err == nil {
+				aggOptions.SetCollation(collation)
+			}
+		case "comment":
+			comment, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("comment must be a string")
+			}
+			aggOptions.SetComment(comment)
+		default:
+			return nil, fmt.Errorf("unsupported aggregate option %q", key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", key, err)
+		}
+	}
+	return aggOptions, nil
+}
+
+// parseHint accepts either an index name or an index key specification.
+func parseHint(value any) (any, error) {
+	switch v := value.(type) {
+	case string:
+		if v == "" {
+			return nil, fmt.Errorf("index name cannot be empty")
+		}
+		return v, nil
+	case map[string]any, bson.M, bson.D, []any:
+		return orderedKeys(v)
+	default:
+		return nil, fmt.Errorf("expected an index name or key document, got %T", value)
+	}
+}
+
+// durationKeys maps the millisecond option names scripts use to the
+// time.Duration fields of the driver option structs.
+var durationKeys = map[string]string{
+	"maxTimeMS":      "MaxTime",
+	"maxAwaitTimeMS": "MaxAwaitTime",
+}
+
+// findOptionsFromExtJSON decodes an Extended JSON document straight into the
+// driver's find options, so any option the driver supports can be set. Keys
+// are matched case-insensitively against the option field names, e.g.
+// showRecordId, returnKey, min, max or noCursorTimeout.
+func findOptionsFromExtJSON(optsJSON string) (*options.FindOptions, error) {
+	var raw bson.M
+	if err := bson.UnmarshalExtJSON([]byte(optsJSON), false, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse find options: %w", err)
+	}
+	for key, field := range durationKeys {
+		value, ok := raw[key]
+		if !ok {
+			continue
+		}
+		d, err := toDurationMS(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", key, err)
+		}
+		delete(raw, key)
+		raw[field] = int64(d)
+	}
+
+	bsonBytes, err := bson.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal find options: %w", err)
+	}
+	findOptions := options.Find()
+	if err := bson.Unmarshal(bsonBytes, findOptions); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal find options: %w", err)
+	}
+	return findOptions, nil
+}
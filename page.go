@@ -0,0 +1,96 @@
+package xk6_mongo
+
+import (
+	"fmt"
+	"log"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// PageResult is one page of documents together with the number of documents
+// matching the filter across all pages.
+type PageResult struct {
+	Total int64    `js:"total"`
+	Items []bson.M `js:"items"`
+}
+
+// FindPage returns the page of documents matching filter selected by sort,
+// skip and limit, along with the total number of matching documents. Both
+// come from a single $facet aggregation, so they are consistent with each
+// other even under concurrent writes. A limit of 0 returns all documents
+// after skip. As with any $facet, the page must fit in a 16MB result
+// document. Like other key specifications, sort can be given as an array of
+// [field, direction] pairs to keep the order of its keys.
+func (c *Client) FindPage(database string, collection string, filter any, sort any, skip int64, limit int64) (*PageResult, error) {
+	filter = c.coerceFilter(filter)
+	ctx, cancel := c.operationContext()
+	defer cancel()
+
+	if skip < 0 || limit < 0 {
+		return nil, fmt.Errorf("skip and limit must not be negative, got %d and %d", skip, limit)
+	}
+	if filter == nil {
+		filter = bson.D{}
+	}
+	sort, err := orderedKeys(sort)
+	if err != nil {
+		log.Printf("Error while preparing sort: %v", err)
+		return nil, err
+	}
+
+	if keys, ok := sort.(map[string]any); ok && len(keys) == 0 {
+		sort = nil
+	}
+
+	items := bson.A{}
+	if sort != nil {
+		items = append(items, bson.D{{Key: "$sort", Value: sort}})
+	}
+	if skip > 0 {
+		items = append(items, bson.D{{Key: "$skip", Value: skip}})
+	}
+	if limit > 0 {
+		items = append(items, bson.D{{Key: "$limit", Value: limit}})
+	}
+	if len(items) == 0 {
+		// $facet sub-pipelines must not be empty.
+		items = append(items, bson.D{{Key: "$match", Value: bson.D{}}})
+	}
+	pipeline := bson.A{
+		bson.D{{Key: "$match", Value: filter}},
+		bson.D{{Key: "$facet", Value: bson.D{
+			{Key: "total", Value: bson.A{bson.D{{Key: "$count", Value: "n"}}}},
+			{Key: "items", Value: items},
+		}}},
+	}
+
+	db := c.client.Database(database)
+	col := db.Collection(collection)
+	cur, err := withRetry(ctx, c, func() (*mongo.Cursor, error) { return col.Aggregate(ctx, pipeline, c.aggregateOptions()) })
+	if err != nil {
+		log.Printf("Error while finding page: %v", err)
+		return nil, err
+	}
+	var results []struct {
+		Total []struct {
+			N int64 `bson:"n"`
+		} `bson:"total"`
+		Items []bson.M `bson:"items"`
+	}
+	if err = cur.All(ctx, &results); err != nil {
+		log.Printf(errDecodingDocuments, err)
+		return nil, err
+	}
+
+	page := &PageResult{Items: []bson.M{}}
+	if len(results) > 0 {
+		if len(results[0].Total) > 0 {
+			page.Total = results[0].Total[0].N
+		}
+		if results[0].Items != nil {
+			page.Items = results[0].Items
+		}
+	}
+	return page, nil
+}
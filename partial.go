@@ -0,0 +1,77 @@
+package xk6_mongo
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// errCodeMaxTimeMSExpired is the server error code of an operation that ran
+// past its maxTimeMS.
+const errCodeMaxTimeMSExpired = 50
+
+// PartialResult holds the documents a find returned before its time limit
+// and whether the limit cut it short.
+type PartialResult struct {
+	Documents []bson.M `js:"documents"`
+	TimedOut  bool     `js:"timedOut"`
+}
+
+// FindPartial runs a find limited to maxTimeMS milliseconds of server time
+// and reads it in batches of batchSize documents (the server default when not
+// positive). When the limit expires it returns the documents of the batches
+// received so far with TimedOut set, instead of failing.
+func (c *Client) FindPartial(database string, collection string, filter any, maxTimeMS int64, batchSize int32) (*PartialResult, error) {
+	filter = c.coerceFilter(filter)
+	ctx, cancel := c.operationContext()
+	defer cancel()
+
+	if maxTimeMS <= 0 {
+		return nil, fmt.Errorf("maxTimeMS must be positive, got %d", maxTimeMS)
+	}
+	opts := options.Find().SetMaxTime(time.Duration(maxTimeMS) * time.Millisecond)
+	if batchSize > 0 {
+		opts.SetBatchSize(batchSize)
+	}
+
+	result := &PartialResult{Documents: []bson.M{}}
+	col := c.client.Database(database).Collection(collection)
+	cur, err := withRetry(ctx, c, func() (*mongo.Cursor, error) { return col.Find(ctx, filter, c.withFindComment(opts)) })
+	if isMaxTimeExpired(err) {
+		result.TimedOut = true
+		return result, nil
+	}
+	if err != nil {
+		log.Printf("Error while finding documents: %v", err)
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	for cur.Next(ctx) {
+		var doc bson.M
+		if err := cur.Decode(&doc); err != nil {
+			log.Printf(errDecodingDocuments, err)
+			return nil, err
+		}
+		result.Documents = append(result.Documents, doc)
+	}
+	if err := cur.Err(); err != nil {
+		if isMaxTimeExpired(err) {
+			result.TimedOut = true
+			return result, nil
+		}
+		log.Printf("Error while iterating documents: %v", err)
+		return nil, err
+	}
+	return result, nil
+}
+
+func isMaxTimeExpired(err error) bool {
+	var serverErr mongo.ServerError
+	return errors.As(err, &serverErr) && serverErr.HasErrorCode(errCodeMaxTimeMSExpired)
+}
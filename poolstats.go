@@ -0,0 +1,70 @@
+package xk6_mongo
+
+import (
+	"sync/atomic"
+
+	"go.mongodb.org/mongo-driver/event"
+)
+
+// PoolStats is a snapshot of the client's connection pools, summed over all
+// servers.
+type PoolStats struct {
+	Total int64 `js:"total"`
+	InUse int64 `js:"inUse"`
+	Idle  int64 `js:"idle"`
+}
+
+// poolCounters counts the connection pool events of a client. Its monitor is
+// installed on every client.
+type poolCounters struct {
+	open  atomic.Int64
+	inUse atomic.Int64
+}
+
+func (p *poolCounters) poolMonitor() *event.PoolMonitor {
+	return &event.PoolMonitor{
+		Event: func(evt *event.PoolEvent) {
+			switch evt.Type {
+			case event.ConnectionCreated:
+				p.open.Add(1)
+			case event.ConnectionClosed:
+				p.open.Add(-1)
+			case event.GetSucceeded:
+				p.inUse.Add(1)
+			case event.ConnectionReturned:
+				p.inUse.Add(-1)
+			}
+		},
+	}
+}
+
+func (p *poolCounters) stats() PoolStats {
+	total := p.open.Load()
+	inUse := min(p.inUse.Load(), total)
+	return PoolStats{Total: total, InUse: inUse, Idle: total - inUse}
+}
+
+// PoolStats returns the number of open connections of the client's pools and
+// how many of them are checked out for an operation or idle. With poolMetrics
+// enabled the same numbers are emitted as gauges on every pool event.
+func (c *Client) PoolStats() PoolStats {
+	return c.pool.stats()
+}
+
+// combinePoolMonitors returns a monitor passing every event to each of the
+// non-nil monitors, in order.
+func combinePoolMonitors(monitors ...*event.PoolMonitor) *event.PoolMonitor {
+	var active []*event.PoolMonitor
+	for _, m := range monitors {
+		if m != nil && m.Event != nil {
+			active = append(active, m)
+		}
+	}
+	return &event.PoolMonitor{
+		Event: func(evt *event.PoolEvent) {
+			for _, m := range active {
+				m.Event(evt)
+			}
+		},
+	}
+}
@@ -0,0 +1,93 @@
+package xk6_mongo
+
+import (
+	"encoding/json"
+	"log"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FindRelaxed is FindWithOptions returning documents decoded from relaxed
+// Extended JSON into plain JS values: numbers of any BSON numeric type become
+// JS numbers, dates become ISO-8601 strings, ObjectIDs become hex strings and
+// decimals become strings, so results can be used in checks without
+// conversion helpers.
+func (c *Client) FindRelaxed(database string, collection string, filter any, opts any) ([]any, error) {
+	filter = c.coerceFilter(filter)
+	call, opts, err := c.withCallTimeout(opts)
+	if err != nil {
+		log.Printf("Error while preparing find options: %v", err)
+		return nil, err
+	}
+	ctx, cancel := call.operationContext()
+	defer cancel()
+
+	findOptions, readPref, err := prepareFindReadOptions(opts)
+	if err != nil {
+		log.Printf("Error while preparing find options: %v", err)
+		return nil, err
+	}
+	db := c.client.Database(database)
+	col := db.Collection(collection, options.Collection().SetReadPreference(readPref))
+	cur, err := withRetry(ctx, c, func() (*mongo.Cursor, error) { return col.Find(ctx, filter, c.withFindComment(findOptions)) })
+	if err != nil {
+		log.Printf("Error while finding documents: %v", err)
+		return nil, err
+	}
+	defer cur.Close(ctx)
+
+	results := []any{}
+	for cur.Next(ctx) {
+		doc, err := relaxedValue(cur.Current)
+		if err != nil {
+			log.Printf(errDecodingDocuments, err)
+			return nil, err
+		}
+		results = append(results, doc)
+	}
+	if err := cur.Err(); err != nil {
+		log.Printf("Error while iterating documents: %v", err)
+		return nil, err
+	}
+	return results, nil
+}
+
+func relaxedValue(raw bson.Raw) (any, error) {
+	data, err := bson.MarshalExtJSON(raw, false, false)
+	if err != nil {
+		return nil, err
+	}
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return simplifyExtJSON(value), nil
+}
+
+// simplifyExtJSON replaces the single-key wrappers relaxed Extended JSON still
+// uses for dates, ObjectIDs and decimals by their string representation.
+func simplifyExtJSON(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		if len(v) == 1 {
+			for _, key := range []string{"$date", "$oid", "$numberDecimal"} {
+				if s, ok := v[key].(string); ok {
+					return s
+				}
+			}
+		}
+		for key, val := range v {
+			v[key] = simplifyExtJSON(val)
+		}
+		return v
+	case []any:
+		for i, val := range v {
+			v[i] = simplifyExtJSON(val)
+		}
+		return v
+	default:
+		return value
+	}
+}
@@ -0,0 +1,41 @@
+package xk6_mongo
+
+import (
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestRelaxedValue(t *testing.T) {
+	oid := primitive.NewObjectID()
+	date := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	raw, err := bson.Marshal(bson.D{
+		{Key: "_id", Value: oid},
+		{Key: "count", Value: int64(42)},
+		{Key: "at", Value: primitive.NewDateTimeFromTime(date)},
+		{Key: "nested", Value: bson.D{{Key: "n", Value: int32(1)}}},
+	})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	value, err := relaxedValue(raw)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	doc := value.(map[string]any)
+	if doc["_id"] != oid.Hex() {
+		t.Fatalf("unexpected _id %v", doc["_id"])
+	}
+	if doc["count"] != float64(42) {
+		t.Fatalf("unexpected count %v", doc["count"])
+	}
+	if doc["at"] != "2024-05-01T12:00:00Z" {
+		t.Fatalf("unexpected date %v", doc["at"])
+	}
+	if doc["nested"].(map[string]any)["n"] != float64(1) {
+		t.Fatalf("unexpected nested document %v", doc["nested"])
+	}
+}
@@ -0,0 +1,71 @@
+package xk6_mongo
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// RestoreBSON inserts the documents of a .bson file written by mongodump into
+// a collection, in unordered batches, and returns the number of inserted
+// documents. The file is streamed, so it does not have to fit in memory, and
+// documents are inserted exactly as stored, preserving every BSON type.
+// Indexes and collection options from the dump's metadata file are not
+// restored.
+func (c *Client) RestoreBSON(database string, collection string, filePath string) (int64, error) {
+	if c.dryRun("restore", database, collection, "file", filePath) {
+		return 0, nil
+	}
+
+	ctx, cancel := c.operationContext()
+	defer cancel()
+
+	f, err := os.Open(filePath)
+	if err != nil {
+		log.Printf("Error while opening BSON dump: %v", err)
+		return 0, err
+	}
+	defer f.Close()
+
+	col := c.client.Database(database).Collection(collection)
+	r := bufio.NewReader(f)
+	var inserted int64
+	batch := make([]any, 0, defaultInsertBatchSize)
+	for {
+		doc, err := bson.NewFromIOReader(r)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			err = fmt.Errorf("error reading document %d of %s: %w", inserted+int64(len(batch)), filePath, err)
+			log.Printf("Error while reading BSON dump: %v", err)
+			return inserted, err
+		}
+		batch = append(batch, doc)
+		if len(batch) < defaultInsertBatchSize {
+			continue
+		}
+		n, err := insertInBatches(ctx, col, batch, len(batch), 0)
+		inserted += n
+		if err != nil {
+			log.Printf("Error while restoring BSON dump: %v", err)
+			return inserted, err
+		}
+		batch = batch[:0]
+	}
+
+	if len(batch) > 0 {
+		n, err := insertInBatches(ctx, col, batch, len(batch), 0)
+		inserted += n
+		if err != nil {
+			log.Printf("Error while restoring BSON dump: %v", err)
+			return inserted, err
+		}
+	}
+	return inserted, nil
+}
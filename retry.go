@@ -0,0 +1,121 @@
+package xk6_mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/topology"
+)
+
+// retryPolicy is how often and how patiently an operation failing with a
+// transient error is retried, see SetRetry.
+type retryPolicy struct {
+	attempts int
+	backoff  time.Duration
+	// writes also retries writes, see SetRetryWrites.
+	writes bool
+}
+
+// retryableCodes are the server error codes of transient failures, mostly
+// raised while a replica set elects a new primary.
+var retryableCodes = []int{
+	6,     // HostUnreachable
+	7,     // HostNotFound
+	89,    // NetworkTimeout
+	91,    // ShutdownInProgress
+	189,   // PrimarySteppedDown
+	9001,  // SocketException
+	10107, // NotWritablePrimary
+	11600, // InterruptedAtShutdown
+	11602, // InterruptedDueToReplStateChange
+	13435, // NotPrimaryNoSecondaryOk
+	13436, // NotPrimaryOrSecondary
+}
+
+// SetRetry makes the client retry an operation that failed with a transient
+// error, such as a network error or a not-primary error during a failover, up
+// to attempts more times. The first retry waits backoffMs milliseconds and
+// every further one twice as long as the previous. Only reads and idempotent
+// commands are retried, and only the command starting an operation, not
+// reading the rest of its cursor; operations inside a transaction are never
+// retried individually. Writes are left to the driver's own retryable writes,
+// see SetRetryWrites. Non-positive attempts disable retrying.
+func (c *Client) SetRetry(attempts int, backoffMs int64) {
+	if attempts <= 0 {
+		c.retry = retryPolicy{writes: c.retry.writes}
+		return
+	}
+	c.retry = retryPolicy{attempts: attempts, backoff: time.Duration(backoffMs) * time.Millisecond, writes: c.retry.writes}
+}
+
+// SetRetryWrites makes the policy set with SetRetry apply to writes as well.
+// A write whose reply was lost may have been applied, so a retried insert can
+// fail with a duplicate key error, and a retried update, delete or
+// findOneAndUpdate is applied twice: an $inc or $push lands twice and
+// findOneAndUpdate may return a different document. Only enable it for
+// scripts whose writes are idempotent; the driver's retryable writes, on by
+// default, already retry a single write once without that risk.
+func (c *Client) SetRetryWrites(enabled bool) {
+	c.retry.writes = enabled
+}
+
+// withRetry runs op under the client's retry policy until it succeeds, fails
+// with an error that is not transient, runs out of attempts or ctx is done.
+func withRetry[T any](ctx context.Context, c *Client, op func() (T, error)) (T, error) {
+	result, err := op()
+	if c.retry.attempts == 0 || mongo.SessionFromContext(ctx) != nil {
+		return result, err
+	}
+
+	backoff := c.retry.backoff
+	for attempt := 0; attempt < c.retry.attempts && isRetryable(err); attempt++ {
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return result, err
+		case <-timer.C:
+		}
+		backoff *= 2
+		result, err = op()
+	}
+	return result, err
+}
+
+// withWriteRetry runs the write op under the client's retry policy if
+// SetRetryWrites enabled it, and exactly once otherwise.
+func withWriteRetry[T any](ctx context.Context, c *Client, op func() (T, error)) (T, error) {
+	if !c.retry.writes {
+		return op()
+	}
+	return withRetry(ctx, c, op)
+}
+
+// isRetryable reports whether err is a transient failure that retrying the
+// same operation may get past.
+func isRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if mongo.IsNetworkError(err) {
+		return true
+	}
+	var selectionErr topology.ServerSelectionError
+	if errors.As(err, &selectionErr) {
+		return true
+	}
+	var serverErr mongo.ServerError
+	if errors.As(err, &serverErr) {
+		if serverErr.HasErrorLabel("RetryableWriteError") {
+			return true
+		}
+		for _, code := range retryableCodes {
+			if serverErr.HasErrorCode(code) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package xk6_mongo
+
+import (
+	"context"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestWithRetry(t *testing.T) {
+	c := &Client{}
+	c.SetRetry(2, 0)
+
+	calls := 0
+	_, err := withRetry(context.Background(), c, func() (any, error) {
+		calls++
+		return nil, mongo.CommandError{Code: 10107, Name: "NotWritablePrimary"}
+	})
+	if err == nil || calls != 3 {
+		t.Fatalf("expected 3 calls and an error, got %d and %v", calls, err)
+	}
+
+	calls = 0
+	_, err = withRetry(context.Background(), c, func() (any, error) {
+		calls++
+		return nil, mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: 11000, Message: "duplicate key"}}}
+	})
+	if err == nil || calls != 1 {
+		t.Fatalf("expected a duplicate key error not to be retried, got %d calls", calls)
+	}
+
+	calls = 0
+	_, err = withRetry(context.Background(), c, func() (any, error) {
+		calls++
+		if calls == 1 {
+			return nil, mongo.CommandError{Labels: []string{"RetryableWriteError"}}
+		}
+		return nil, nil
+	})
+	if err != nil || calls != 2 {
+		t.Fatalf("expected success on the second call, got %d calls and %v", calls, err)
+	}
+
+	if isRetryable(context.DeadlineExceeded) {
+		t.Fatalf("deadline errors must not be retried")
+	}
+}
+
+func TestWithWriteRetry(t *testing.T) {
+	c := &Client{}
+	c.SetRetry(2, 0)
+
+	calls := 0
+	_, err := withWriteRetry(context.Background(), c, func() (any, error) {
+		calls++
+		return nil, mongo.CommandError{Labels: []string{"RetryableWriteError"}}
+	})
+	if err == nil || calls != 1 {
+		t.Fatalf("expected writes not to be retried by default, got %d calls", calls)
+	}
+
+	c.SetRetryWrites(true)
+	calls = 0
+	_, err = withWriteRetry(context.Background(), c, func() (any, error) {
+		calls++
+		return nil, mongo.CommandError{Labels: []string{"RetryableWriteError"}}
+	})
+	if err == nil || calls != 3 {
+		t.Fatalf("expected 3 calls once write retries are enabled, got %d", calls)
+	}
+
+	c.SetRetry(0, 0)
+	if !c.retry.writes {
+		t.Fatalf("disabling retries must keep the write retry opt-in")
+	}
+}
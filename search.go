@@ -0,0 +1,103 @@
+package xk6_mongo
+
+import (
+	"fmt"
+	"log"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	// vectorSearchCandidatesFactor is how many more nearest neighbour
+	// candidates than results VectorSearch considers, the ratio Atlas
+	// recommends as a starting point for good recall.
+	vectorSearchCandidatesFactor = 10
+	// maxVectorSearchCandidates is the largest numCandidates Atlas accepts.
+	maxVectorSearchCandidates = 10000
+)
+
+// VectorSearch returns the k documents whose vector in path is most similar
+// to queryVector, using the Atlas Vector Search index named index, each with
+// its similarity in a score field, most similar first. filter, which may be
+// nil, pre-filters the documents on fields indexed as filter fields.
+func (c *Client) VectorSearch(database string, collection string, index string, path string, queryVector []float64, k int, filter any) ([]bson.M, error) {
+	ctx, cancel := c.operationContext()
+	defer cancel()
+
+	if index == "" || path == "" {
+		return nil, fmt.Errorf("index and path must be set")
+	}
+	if len(queryVector) == 0 {
+		return nil, fmt.Errorf("query vector cannot be empty")
+	}
+	if k <= 0 || k > maxVectorSearchCandidates {
+		return nil, fmt.Errorf("k must be between 1 and %d, got %d", maxVectorSearchCandidates, k)
+	}
+
+	stage := bson.D{
+		{Key: "index", Value: index},
+		{Key: "path", Value: path},
+		{Key: "queryVector", Value: queryVector},
+		{Key: "numCandidates", Value: min(k*vectorSearchCandidatesFactor, maxVectorSearchCandidates)},
+		{Key: "limit", Value: k},
+	}
+	if filter != nil {
+		stage = append(stage, bson.E{Key: "filter", Value: c.coerceFilter(filter)})
+	}
+	pipeline := bson.A{
+		bson.D{{Key: "$vectorSearch", Value: stage}},
+		bson.D{{Key: "$set", Value: bson.D{{Key: "score", Value: bson.D{{Key: "$meta", Value: "vectorSearchScore"}}}}}},
+	}
+
+	col := c.client.Database(database).Collection(collection)
+	cur, err := withRetry(ctx, c, func() (*mongo.Cursor, error) { return col.Aggregate(ctx, pipeline, c.aggregateOptions()) })
+	if err != nil {
+		log.Printf("Error while running vector search: %v", err)
+		return nil, err
+	}
+	var results []bson.M
+	if err = cur.All(ctx, &results); err != nil {
+		log.Printf(errDecodingDocuments, err)
+		return nil, err
+	}
+	return results, nil
+}
+
+// Search runs an Atlas Search query against the search index named index,
+// follows it with the stages of pipeline, which may be empty, and returns the
+// results. query holds the operator of the $search stage and its other
+// options, e.g. { text: { query: "space", path: "title" } }.
+func (c *Client) Search(database string, collection string, index string, query any, pipeline []any) ([]bson.M, error) {
+	ctx, cancel := c.operationContext()
+	defer cancel()
+
+	raw, err := optionsMap(query)
+	if err != nil {
+		return nil, fmt.Errorf("invalid search query: %w", err)
+	}
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("search query cannot be empty")
+	}
+	stage := bson.M{}
+	for key, value := range raw {
+		stage[key] = value
+	}
+	if index != "" {
+		stage["index"] = index
+	}
+	searchPipeline := append(bson.A{bson.D{{Key: "$search", Value: stage}}}, pipeline...)
+
+	col := c.client.Database(database).Collection(collection)
+	cur, err := withRetry(ctx, c, func() (*mongo.Cursor, error) { return col.Aggregate(ctx, searchPipeline, c.aggregateOptions()) })
+	if err != nil {
+		log.Printf("Error while running search: %v", err)
+		return nil, err
+	}
+	var results []bson.M
+	if err = cur.All(ctx, &results); err != nil {
+		log.Printf(errDecodingDocuments, err)
+		return nil, err
+	}
+	return results, nil
+}
@@ -0,0 +1,64 @@
+package xk6_mongo
+
+import (
+	"fmt"
+	"log"
+	"math"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// seedChunkSize is the number of sequence numbers generated per intermediate
+// document by SeedDocuments, which stays well below the 16MB document limit.
+const seedChunkSize = 10000
+
+// SeedDocuments has the server generate count documents from template and
+// write them to collection with $out, replacing its contents, instead of
+// sending them from the VU. The template is an aggregation expression
+// evaluated once per document, in which "$i" is the document's sequence
+// number from 0 to count-1, e.g. {sku: {$concat: ["sku-", {$toString: "$i"}]},
+// price: {$multiply: [{$rand: {}}, 100]}}. Documents get a generated _id
+// unless the template sets one. It requires MongoDB 5.1 or later.
+func (c *Client) SeedDocuments(database string, collection string, count int64, template any) error {
+	if c.dryRun("seedDocuments", database, collection, "count", count, "template", template) {
+		return nil
+	}
+
+	if count <= 0 || count > math.MaxInt32 {
+		// $range only takes 32-bit integers.
+		return fmt.Errorf("count must be between 1 and %d, got %d", math.MaxInt32, count)
+	}
+	if template == nil {
+		return fmt.Errorf("template cannot be nil")
+	}
+
+	ctx, cancel := c.operationContext()
+	defer cancel()
+
+	cur, err := c.client.Database(database).Aggregate(ctx, seedPipeline(collection, count, template))
+	if err != nil {
+		log.Printf("Error while seeding documents into %s.%s: %v", database, collection, err)
+		return err
+	}
+	return cur.Close(ctx)
+}
+
+// seedPipeline generates the sequence numbers in chunks of seedChunkSize,
+// unwinds them into one document each and replaces every document with the
+// template.
+func seedPipeline(collection string, count int64, template any) []any {
+	chunks := (count + seedChunkSize - 1) / seedChunkSize
+	chunkStart := bson.D{{Key: "$multiply", Value: bson.A{"$chunk", seedChunkSize}}}
+	chunkEnd := bson.D{{Key: "$min", Value: bson.A{
+		bson.D{{Key: "$multiply", Value: bson.A{bson.D{{Key: "$add", Value: bson.A{"$chunk", 1}}}, seedChunkSize}}},
+		count,
+	}}}
+	return []any{
+		bson.D{{Key: "$documents", Value: bson.A{bson.D{{Key: "chunk", Value: bson.D{{Key: "$range", Value: bson.A{0, chunks}}}}}}}},
+		bson.D{{Key: "$unwind", Value: "$chunk"}},
+		bson.D{{Key: "$project", Value: bson.D{{Key: "i", Value: bson.D{{Key: "$range", Value: bson.A{chunkStart, chunkEnd}}}}}}},
+		bson.D{{Key: "$unwind", Value: "$i"}},
+		bson.D{{Key: "$replaceWith", Value: template}},
+		bson.D{{Key: "$out", Value: collection}},
+	}
+}
@@ -0,0 +1,147 @@
+package xk6_mongo
+
+import (
+	"context"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+
+	k6modules "go.k6.io/k6/js/modules"
+)
+
+// slowLog logs the commands of a client that take longer than a threshold.
+// Its command monitor is installed on every client and does nothing until a
+// threshold is set.
+type slowLog struct {
+	vu        k6modules.VU
+	threshold atomic.Int64
+	// started holds the redacted filter of every command in flight while a
+	// threshold is set, keyed by request ID.
+	started sync.Map
+}
+
+// SetSlowLogThreshold makes the client log every command that takes longer
+// than ms milliseconds, as measured by the driver, through the k6 logger
+// with its duration, database and filter or pipeline. Filter values are
+// redacted, leaving only the field names and operators. A non-positive value
+// turns the slow log off.
+func (c *Client) SetSlowLogThreshold(ms int64) {
+	c.slowLog.threshold.Store(int64(time.Duration(max(ms, 0)) * time.Millisecond))
+}
+
+func (s *slowLog) commandMonitor() *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Started: func(_ context.Context, evt *event.CommandStartedEvent) {
+			if s.threshold.Load() > 0 {
+				s.started.Store(evt.RequestID, redactedFilter(evt.Command))
+			}
+		},
+		Succeeded: func(_ context.Context, evt *event.CommandSucceededEvent) {
+			s.finished(evt.CommandFinishedEvent, "succeeded")
+		},
+		Failed: func(_ context.Context, evt *event.CommandFailedEvent) {
+			s.finished(evt.CommandFinishedEvent, "failed")
+		},
+	}
+}
+
+func (s *slowLog) finished(evt event.CommandFinishedEvent, status string) {
+	filter, ok := s.started.LoadAndDelete(evt.RequestID)
+	threshold := time.Duration(s.threshold.Load())
+	if !ok || threshold <= 0 || evt.Duration < threshold {
+		return
+	}
+	durationMS := float64(evt.Duration.Microseconds()) / 1000
+	if s.vu == nil || s.vu.State() == nil {
+		log.Printf("Slow MongoDB command %s on %s %s in %.1fms: %s", evt.CommandName, evt.DatabaseName, status, durationMS, filter)
+		return
+	}
+	s.vu.State().Logger.
+		WithField("command", evt.CommandName).
+		WithField("database", evt.DatabaseName).
+		WithField("status", status).
+		WithField("durationMs", durationMS).
+		WithField("filter", filter).
+		Warn("Slow MongoDB command")
+}
+
+// redactedFilter returns the filter, query or pipeline of a command as
+// Extended JSON with every value replaced by "?".
+func redactedFilter(command bson.Raw) string {
+	for _, key := range []string{"filter", "query", "pipeline"} {
+		if value, err := command.LookupErr(key); err == nil {
+			return formatDryRunValue(redactValue(value))
+		}
+	}
+	for _, key := range []string{"updates", "deletes"} {
+		if value, err := command.LookupErr(key, "0", "q"); err == nil {
+			return formatDryRunValue(redactValue(value))
+		}
+	}
+	return ""
+}
+
+func redactValue(value bson.RawValue) any {
+	switch value.Type {
+	case bson.TypeEmbeddedDocument:
+		elems, err := value.Document().Elements()
+		if err != nil {
+			return "?"
+		}
+		doc := make(bson.D, len(elems))
+		for i, elem := range elems {
+			doc[i] = bson.E{Key: elem.Key(), Value: redactValue(elem.Value())}
+		}
+		return doc
+	case bson.TypeArray:
+		values, err := value.Array().Values()
+		if err != nil {
+			return "?"
+		}
+		arr := make(bson.A, len(values))
+		for i, v := range values {
+			arr[i] = redactValue(v)
+		}
+		return arr
+	default:
+		return "?"
+	}
+}
+
+// combineCommandMonitors returns a monitor passing every event to each of
+// the non-nil monitors.
+func combineCommandMonitors(monitors ...*event.CommandMonitor) *event.CommandMonitor {
+	var active []*event.CommandMonitor
+	for _, m := range monitors {
+		if m != nil {
+			active = append(active, m)
+		}
+	}
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, evt *event.CommandStartedEvent) {
+			for _, m := range active {
+				if m.Started != nil {
+					m.Started(ctx, evt)
+				}
+			}
+		},
+		Succeeded: func(ctx context.Context, evt *event.CommandSucceededEvent) {
+			for _, m := range active {
+				if m.Succeeded != nil {
+					m.Succeeded(ctx, evt)
+				}
+			}
+		},
+		Failed: func(ctx context.Context, evt *event.CommandFailedEvent) {
+			for _, m := range active {
+				if m.Failed != nil {
+					m.Failed(ctx, evt)
+				}
+			}
+		},
+	}
+}
@@ -0,0 +1,51 @@
+package xk6_mongo
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestRedactedFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		command bson.D
+		want    string
+	}{
+		{
+			name: "find",
+			command: bson.D{
+				{Key: "find", Value: "users"},
+				{Key: "filter", Value: bson.D{{Key: "email", Value: "a@example.com"}, {Key: "age", Value: bson.D{{Key: "$gt", Value: 30}}}}},
+			},
+			want: `{"email":"?","age":{"$gt":"?"}}`,
+		},
+		{
+			name: "delete",
+			command: bson.D{
+				{Key: "delete", Value: "users"},
+				{Key: "deletes", Value: bson.A{bson.D{
+					{Key: "q", Value: bson.D{{Key: "_id", Value: bson.D{{Key: "$in", Value: bson.A{1, 2}}}}}},
+					{Key: "limit", Value: 0},
+				}}},
+			},
+			want: `{"_id":{"$in":["?","?"]}}`,
+		},
+		{
+			name:    "no filter",
+			command: bson.D{{Key: "ping", Value: 1}},
+			want:    "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw, err := bson.Marshal(tt.command)
+			if err != nil {
+				t.Fatalf("marshal: %v", err)
+			}
+			if got := redactedFilter(raw); got != tt.want {
+				t.Errorf("redactedFilter() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
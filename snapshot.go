@@ -0,0 +1,51 @@
+package xk6_mongo
+
+import (
+	"fmt"
+	"log"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// WithSnapshot runs callback with a client bound to a snapshot session, so
+// every find, aggregate and distinct issued through it reads with read concern
+// "snapshot" at the cluster time atClusterTime, e.g. one returned by
+// ClusterTime, and sees the same data regardless of concurrent writes. With a
+// zero atClusterTime the server picks the time on the first read and the
+// following reads reuse it. Snapshot sessions do not support writes and
+// require MongoDB 5.0 or later.
+func (c *Client) WithSnapshot(atClusterTime primitive.Timestamp, callback func(*Client) error) error {
+	if callback == nil {
+		return fmt.Errorf("callback cannot be nil")
+	}
+
+	session, err := c.client.StartSession(options.Session().SetSnapshot(true))
+	if err != nil {
+		log.Printf("Error while starting snapshot session: %v", err)
+		return err
+	}
+	defer session.EndSession(c.context())
+
+	if !atClusterTime.IsZero() {
+		// The driver only learns the snapshot time from the first read's reply
+		// and offers no option to set it, so it is set on the session itself.
+		xs, ok := session.(mongo.XSession)
+		if !ok {
+			return fmt.Errorf("driver session does not support setting the snapshot time")
+		}
+		xs.ClientSession().SnapshotTime = &atClusterTime
+	}
+
+	err = mongo.WithSession(c.context(), session, func(sessCtx mongo.SessionContext) error {
+		snap := *c
+		snap.ctx = sessCtx
+		return callback(&snap)
+	})
+	if err != nil {
+		log.Printf("Error while reading from snapshot: %v", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package xk6_mongo
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// collectionSnapshotName and collectionRestoreName name the collections
+// Snapshot and Restore work with, next to the collection itself.
+func collectionSnapshotName(collection string) string {
+	return collection + "__snapshot"
+}
+
+func collectionRestoreName(collection string) string {
+	return collection + "__restore"
+}
+
+// Snapshot copies the documents, indexes and options, such as the validator
+// and collation, of the collection into a snapshot collection next to it,
+// replacing any previous snapshot, so that Restore can later reset the
+// collection to this state. Capped and time-series collections cannot be
+// snapshotted, as $out cannot write into them.
+func (c *Client) Snapshot(database string, collection string) error {
+	if c.dryRun("snapshot", database, collection) {
+		return nil
+	}
+
+	ctx, cancel := c.operationContext()
+	defer cancel()
+
+	db := c.client.Database(database)
+	if err := copyCollection(ctx, db, collection, collectionSnapshotName(collection)); err != nil {
+		log.Printf("Error while taking a snapshot of %s.%s: %v", database, collection, err)
+		return err
+	}
+	return nil
+}
+
+// Restore resets the collection to the state of its last Snapshot. The
+// snapshot is copied into a staging collection, which is then renamed over
+// the collection, so readers see either the old or the restored contents,
+// and the snapshot stays available for further restores.
+func (c *Client) Restore(database string, collection string) error {
+	if c.dryRun("restoreSnapshot", database, collection) {
+		return nil
+	}
+
+	ctx, cancel := c.operationContext()
+	defer cancel()
+
+	db := c.client.Database(database)
+	snapshot := collectionSnapshotName(collection)
+	names, err := db.ListCollectionNames(ctx, bson.D{{Key: "name", Value: snapshot}})
+	if err != nil {
+		log.Printf("Error while looking for the snapshot of %s.%s: %v", database, collection, err)
+		return err
+	}
+	if len(names) == 0 {
+		return fmt.Errorf("%s.%s has no snapshot, call snapshot first", database, collection)
+	}
+
+	staging := collectionRestoreName(collection)
+	if err := copyCollection(ctx, db, snapshot, staging); err != nil {
+		log.Printf("Error while restoring %s.%s: %v", database, collection, err)
+		return err
+	}
+	cmd := bson.D{
+		{Key: "renameCollection", Value: database + "." + staging},
+		{Key: "to", Value: database + "." + collection},
+		{Key: "dropTarget", Value: true},
+	}
+	if err := c.client.Database("admin").RunCommand(ctx, cmd).Err(); err != nil {
+		log.Printf("Error while restoring %s.%s: %v", database, collection, err)
+		return err
+	}
+	return nil
+}
+
+// copyCollection replaces the collection to with the documents of from using
+// $out. As $out carries over neither the options nor the indexes of from, to
+// is first created with the options of from, and the secondary indexes of
+// from are recreated on it afterwards.
+func copyCollection(ctx context.Context, db *mongo.Database, from string, to string) error {
+	collections, err := db.ListCollectionSpecifications(ctx, bson.D{{Key: "name", Value: from}})
+	if err != nil {
+		return fmt.Errorf("reading collection options: %w", err)
+	}
+	var collOpts bson.Raw
+	if len(collections) > 0 {
+		collOpts = collections[0].Options
+	}
+	if capped, _ := collOpts.Lookup("capped").BooleanOK(); capped {
+		return fmt.Errorf("%s is a capped collection, which $out cannot write into", from)
+	}
+	if _, err := collOpts.LookupErr("timeseries"); err == nil {
+		return fmt.Errorf("%s is a time-series collection, which $out cannot write into", from)
+	}
+	elements, err := collOpts.Elements()
+	if err != nil {
+		return fmt.Errorf("reading collection options: %w", err)
+	}
+
+	if err := db.Collection(to).Drop(ctx); err != nil {
+		return fmt.Errorf("dropping %s: %w", to, err)
+	}
+	if len(elements) > 0 {
+		cmd := bson.D{{Key: "create", Value: to}}
+		for _, element := range elements {
+			cmd = append(cmd, bson.E{Key: element.Key(), Value: element.Value()})
+		}
+		if err := db.RunCommand(ctx, cmd).Err(); err != nil {
+			return fmt.Errorf("creating %s: %w", to, err)
+		}
+	}
+
+	// The documents passed the validator of from already, or were let in by
+	// its validation level, so they are copied as they are.
+	src := db.Collection(from)
+	opts := options.Aggregate().SetBypassDocumentValidation(true)
+	cur, err := src.Aggregate(ctx, bson.A{bson.D{{Key: "$out", Value: to}}}, opts)
+	if err != nil {
+		return fmt.Errorf("copying documents: %w", err)
+	}
+	if err := cur.Close(ctx); err != nil {
+		return fmt.Errorf("copying documents: %w", err)
+	}
+
+	specs, err := src.Indexes().List(ctx)
+	if err != nil {
+		return fmt.Errorf("listing indexes: %w", err)
+	}
+	var indexes []bson.M
+	if err := specs.All(ctx, &indexes); err != nil {
+		return fmt.Errorf("listing indexes: %w", err)
+	}
+	var secondary bson.A
+	for _, index := range indexes {
+		if index["name"] == "_id_" {
+			continue
+		}
+		delete(index, "ns")
+		secondary = append(secondary, index)
+	}
+	if len(secondary) == 0 {
+		return nil
+	}
+	cmd := bson.D{{Key: "createIndexes", Value: to}, {Key: "indexes", Value: secondary}}
+	if err := db.RunCommand(ctx, cmd).Err(); err != nil {
+		return fmt.Errorf("copying indexes: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package xk6_mongo
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// tailAwaitTime is how long each getMore of a tailable cursor waits on the
+// server for new documents, which bounds how far TailCursor.Next can overrun
+// its timeout.
+const tailAwaitTime = 100 * time.Millisecond
+
+// TailCursor is a tailable-await cursor over a capped collection, to consume
+// it like a message queue. Close should be called once the script is done
+// with it, otherwise it is closed on Disconnect or when the VU stops.
+type TailCursor struct {
+	cursor  *mongo.Cursor
+	ctx     context.Context
+	tracker *openCursors
+	// open restarts the cursor, see Next.
+	open func() (*mongo.Cursor, error)
+	seen bool
+
+	// mu serializes the script's calls with the close on VU stop.
+	mu     sync.Mutex
+	closed bool
+}
+
+// Next returns the next document, waiting up to timeoutMs milliseconds for
+// one to be inserted, or nil if none arrived in time. With a timeoutMs of 0
+// it only checks once. A tailable cursor on a collection that had no matching
+// document yet is closed by the server right away, so until Next has returned
+// a document it restarts the cursor as needed.
+func (t *TailCursor) Next(timeoutMs int64) (bson.M, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		return nil, fmt.Errorf("tailable cursor is closed")
+	}
+	deadline := time.Now().Add(time.Duration(timeoutMs) * time.Millisecond)
+	for {
+		if t.cursor.TryNext(t.ctx) {
+			var doc bson.M
+			if err := t.cursor.Decode(&doc); err != nil {
+				log.Printf(errDecodingDocuments, err)
+				return nil, err
+			}
+			t.seen = true
+			return doc, nil
+		}
+		if err := t.cursor.Err(); err != nil {
+			log.Printf("Error while tailing collection: %v", err)
+			return nil, err
+		}
+		if t.cursor.ID() == 0 {
+			if t.seen {
+				return nil, fmt.Errorf("tailable cursor was closed by the server")
+			}
+			if !time.Now().Before(deadline) {
+				return nil, nil
+			}
+			// Without a getMore to wait on, pace the restarts.
+			time.Sleep(min(tailAwaitTime, time.Until(deadline)))
+			cur, err := t.open()
+			if err != nil {
+				log.Printf("Error while restarting tailable cursor: %v", err)
+				return nil, err
+			}
+			t.cursor = cur
+			continue
+		}
+		if !time.Now().Before(deadline) {
+			return nil, nil
+		}
+	}
+}
+
+// Close releases the server-side cursor. Closing it again does nothing.
+func (t *TailCursor) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		return nil
+	}
+	t.closed = true
+	t.tracker.untrack(t)
+	if err := t.cursor.Close(t.ctx); err != nil {
+		log.Printf("Error while closing tailable cursor: %v", err)
+		return err
+	}
+	return nil
+}
+
+// TailCapped opens a tailable-await cursor over the documents of a capped
+// collection matching filter, starting with the oldest, that keeps returning
+// documents as they are inserted. The collection can be created with the
+// capped option of CreateCollection.
+func (c *Client) TailCapped(database string, collection string, filter any) (*TailCursor, error) {
+	filter = c.coerceFilter(filter)
+	col := c.client.Database(database).Collection(collection)
+	opts := c.withFindComment(options.Find().SetCursorType(options.TailableAwait).SetMaxAwaitTime(tailAwaitTime))
+	ctx := c.context()
+	open := func() (*mongo.Cursor, error) { return col.Find(ctx, filter, opts) }
+
+	cur, err := open()
+	if err != nil {
+		log.Printf("Error while tailing collection: %v", err)
+		return nil, err
+	}
+	tail := &TailCursor{cursor: cur, ctx: ctx, tracker: c.cursors, open: open}
+	c.trackCursor(tail)
+	return tail, nil
+}
@@ -0,0 +1,116 @@
+package xk6_mongo
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// defaultTimeField is the time field of the documents InsertTimeSeries
+// writes to a collection that is not a time series collection.
+const defaultTimeField = "timestamp"
+
+// InsertTimeSeries inserts one measurement every intervalMs milliseconds from
+// start, inclusive, to end, exclusive, and returns the number of inserted
+// documents. Each document holds its time in the collection's timeField, or
+// "timestamp" if the collection is not a time series collection, and meta in
+// metaField. valueGen is called with the time and sequence number of every
+// measurement and returns either an object, whose fields are added to the
+// document, or a single value stored as "value". Documents are generated and
+// inserted in unordered batches of 1000, so that long windows do not have to
+// be held in memory.
+func (c *Client) InsertTimeSeries(database string, collection string, metaField string, meta any, start time.Time, end time.Time, intervalMs int64, valueGen func(time.Time, int64) (any, error)) (int64, error) {
+	if intervalMs <= 0 {
+		return 0, fmt.Errorf("interval must be positive, got %d", intervalMs)
+	}
+	if !end.After(start) {
+		return 0, fmt.Errorf("end %s must be after start %s", end.Format(time.RFC3339), start.Format(time.RFC3339))
+	}
+	if valueGen == nil {
+		return 0, fmt.Errorf("value generator cannot be nil")
+	}
+	interval := time.Duration(intervalMs) * time.Millisecond
+	count := int64(end.Sub(start)-1)/int64(interval) + 1
+	if c.dryRun("insertTimeSeries", database, collection, "metaField", metaField, "meta", meta, "documents", count) {
+		return 0, nil
+	}
+
+	ctx, cancel := c.operationContext()
+	defer cancel()
+
+	db := c.client.Database(database)
+	timeField, err := timeSeriesTimeField(ctx, db, collection)
+	if err != nil {
+		log.Printf("Error while reading collection options: %v", err)
+		return 0, err
+	}
+
+	col := db.Collection(collection)
+	var inserted int64
+	batch := make([]any, 0, defaultInsertBatchSize)
+	for i := int64(0); i < count; i++ {
+		ts := start.Add(time.Duration(i) * interval)
+		value, err := valueGen(ts, i)
+		if err != nil {
+			return inserted, err
+		}
+		doc, err := timeSeriesPoint(timeField, metaField, meta, ts, value)
+		if err != nil {
+			return inserted, fmt.Errorf("measurement %d: %w", i, err)
+		}
+		batch = append(batch, doc)
+		if len(batch) < defaultInsertBatchSize && i < count-1 {
+			continue
+		}
+		n, err := insertInBatches(ctx, col, batch, defaultInsertBatchSize, 0)
+		inserted += n
+		if err != nil {
+			log.Printf("Error while inserting time series documents: %v", err)
+			return inserted, err
+		}
+		batch = batch[:0]
+	}
+	return inserted, nil
+}
+
+// timeSeriesTimeField returns the timeField of a time series collection, or
+// defaultTimeField for other and missing collections.
+func timeSeriesTimeField(ctx context.Context, db *mongo.Database, collection string) (string, error) {
+	specs, err := db.ListCollectionSpecifications(ctx, bson.D{{Key: "name", Value: collection}})
+	if err != nil {
+		return "", err
+	}
+	if len(specs) == 0 || specs[0].Type != "timeseries" {
+		return defaultTimeField, nil
+	}
+	timeField, ok := specs[0].Options.Lookup("timeseries", "timeField").StringValueOK()
+	if !ok {
+		return defaultTimeField, nil
+	}
+	return timeField, nil
+}
+
+func timeSeriesPoint(timeField string, metaField string, meta any, ts time.Time, value any) (bson.D, error) {
+	doc := bson.D{{Key: timeField, Value: ts}}
+	if metaField != "" {
+		doc = append(doc, bson.E{Key: metaField, Value: meta})
+	}
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if key == timeField || key == metaField {
+				return nil, fmt.Errorf("value generator cannot set %q", key)
+			}
+			doc = append(doc, bson.E{Key: key, Value: field})
+		}
+	case nil:
+		return nil, fmt.Errorf("value generator returned no value")
+	default:
+		doc = append(doc, bson.E{Key: "value", Value: v})
+	}
+	return doc, nil
+}
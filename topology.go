@@ -0,0 +1,154 @@
+package xk6_mongo
+
+import (
+	"fmt"
+	"log"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/event"
+	"go.mongodb.org/mongo-driver/mongo/description"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+// topologyWatcher follows the SDAM events of a client, keeps the latest
+// topology description and tells subscribers when a new writable primary has
+// been discovered.
+type topologyWatcher struct {
+	mu          sync.Mutex
+	primary     string
+	description description.Topology
+	subscribers []chan string
+}
+
+func (w *topologyWatcher) serverMonitor() *event.ServerMonitor {
+	return &event.ServerMonitor{
+		TopologyDescriptionChanged: func(evt *event.TopologyDescriptionChangedEvent) {
+			w.setDescription(evt.NewDescription)
+			w.update(writablePrimary(evt.NewDescription))
+		},
+	}
+}
+
+func (w *topologyWatcher) update(primary string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if primary == w.primary {
+		return
+	}
+	w.primary = primary
+	if primary == "" {
+		return
+	}
+	for _, ch := range w.subscribers {
+		ch <- primary
+	}
+	w.subscribers = nil
+}
+
+func (w *topologyWatcher) setDescription(topology description.Topology) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.description = topology
+}
+
+// servers returns the servers of the latest topology description.
+func (w *topologyWatcher) servers() []description.Server {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.description.Servers
+}
+
+func (w *topologyWatcher) current() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.primary
+}
+
+func (w *topologyWatcher) subscribe() chan string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	ch := make(chan string, 1)
+	w.subscribers = append(w.subscribers, ch)
+	return ch
+}
+
+func (w *topologyWatcher) unsubscribe(ch chan string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for i, sub := range w.subscribers {
+		if sub == ch {
+			w.subscribers = append(w.subscribers[:i], w.subscribers[i+1:]...)
+			return
+		}
+	}
+}
+
+func writablePrimary(topology description.Topology) string {
+	for _, server := range topology.Servers {
+		if server.Kind == description.RSPrimary || server.Kind == description.Standalone {
+			return server.Addr.String()
+		}
+	}
+	return ""
+}
+
+// PrimaryHost returns the address, as host:port, of the current writable
+// primary, or of the server for a standalone deployment, e.g. to step down or
+// inject a fault on the right node. If the client has not discovered a
+// primary yet, it waits for one with a ping, bounded by the server selection
+// timeout. Sharded clusters have no single primary and return an error.
+func (c *Client) PrimaryHost() (string, error) {
+	if primary := c.topology.current(); primary != "" {
+		return primary, nil
+	}
+
+	ctx, cancel := c.operationContext()
+	defer cancel()
+
+	if err := c.client.Ping(ctx, readpref.Primary()); err != nil {
+		log.Printf("Error while looking for the primary: %v", err)
+		return "", err
+	}
+	primary := c.topology.current()
+	if primary == "" {
+		return "", fmt.Errorf("the deployment has no writable primary")
+	}
+	return primary, nil
+}
+
+// OnTopologyChange calls callback with the address of the next writable
+// primary that differs from the current one, e.g. once a replica set has
+// elected a new primary after a step-down. The callback runs once, on the
+// VU's event loop, and the iteration does not finish before it has run or
+// the test is stopped.
+func (c *Client) OnTopologyChange(callback func(string) error) error {
+	if callback == nil {
+		return fmt.Errorf("callback cannot be nil")
+	}
+	if c.vu == nil {
+		return fmt.Errorf("topology callbacks require running under k6")
+	}
+
+	ch := c.topology.subscribe()
+	enqueue := c.vu.RegisterCallback()
+	ctx := c.vu.Context()
+	go func() {
+		select {
+		case primary := <-ch:
+			enqueue(func() error {
+				return callback(primary)
+			})
+		case <-ctx.Done():
+			c.topology.unsubscribe(ch)
+			log.Print("Stopped waiting for a topology change")
+			enqueue(func() error { return nil })
+		}
+	}()
+	return nil
+}